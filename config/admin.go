@@ -0,0 +1,12 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// AdminAPIKey is the key admin-only endpoints expect in the X-Admin-Key header
+// (ADMIN_API_KEY). Empty means no request is treated as admin.
+func AdminAPIKey() string {
+	return strings.TrimSpace(os.Getenv("ADMIN_API_KEY"))
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// CommissionSlab pays PerSale for each unit of sales credit from From upwards,
+// until the next slab starts.
+type CommissionSlab struct {
+	From    float64 `json:"from"`
+	PerSale float64 `json:"perSale"`
+}
+
+// CommissionRule is the slab table for one staff profile. With Retroactive,
+// every unit is paid at the highest slab reached instead of slab by slab.
+type CommissionRule struct {
+	Slabs       []CommissionSlab `json:"slabs"`
+	Retroactive bool             `json:"retroactive"`
+}
+
+// CommissionRules reads per-profile rules from the JSON object in the file
+// named by COMMISSION_RULES_FILE. Profile keys are lower-cased; "*" applies to
+// profiles without their own rule. Example file:
+//
+//	{"counsellor": {"slabs": [{"from": 0, "perSale": 500}, {"from": 10, "perSale": 750}]},
+//	 "*": {"slabs": [{"from": 0, "perSale": 300}]}}
+//
+// An unset variable means no rules; a file that cannot be read or parsed is an
+// error, so commission is never silently reported as unconfigured.
+func CommissionRules() (map[string]CommissionRule, error) {
+	return readCommissionRules(os.Getenv("COMMISSION_RULES_FILE"))
+}
+
+func readCommissionRules(path string) (map[string]CommissionRule, error) {
+	rules := map[string]CommissionRule{}
+	if path == "" {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading commission rules: %w", err)
+	}
+
+	var raw map[string]CommissionRule
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing commission rules %s: %w", path, err)
+	}
+
+	for profile, rule := range raw {
+		sort.Slice(rule.Slabs, func(i, j int) bool {
+			return rule.Slabs[i].From < rule.Slabs[j].From
+		})
+		rules[strings.ToLower(strings.TrimSpace(profile))] = rule
+	}
+	return rules, nil
+}
+
+// CommissionRuleFor returns the rule for profile, falling back to "*".
+func CommissionRuleFor(rules map[string]CommissionRule, profile string) (CommissionRule, bool) {
+	if rule, ok := rules[strings.ToLower(strings.TrimSpace(profile))]; ok {
+		return rule, true
+	}
+	rule, ok := rules["*"]
+	return rule, ok
+}
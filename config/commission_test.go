@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCommissionRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commission.json")
+	data := `{" Counsellor ": {"slabs": [{"from": 10, "perSale": 750}, {"from": 0, "perSale": 500}]},
+	          "*": {"slabs": [{"from": 0, "perSale": 300}], "retroactive": true}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("COMMISSION_RULES_FILE", path)
+
+	want := map[string]CommissionRule{
+		"counsellor": {Slabs: []CommissionSlab{{From: 0, PerSale: 500}, {From: 10, PerSale: 750}}},
+		"*":          {Slabs: []CommissionSlab{{From: 0, PerSale: 300}}, Retroactive: true},
+	}
+	if got, err := CommissionRules(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("CommissionRules() = %+v, %v; want %+v", got, err, want)
+	}
+
+	t.Setenv("COMMISSION_RULES_FILE", "")
+	if got, err := CommissionRules(); err != nil || len(got) != 0 {
+		t.Errorf("CommissionRules() without a file = %+v, %v; want none", got, err)
+	}
+
+	t.Setenv("COMMISSION_RULES_FILE", filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := CommissionRules(); err == nil {
+		t.Error("CommissionRules() with a missing file: expected an error")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"counsellor": {"slabs": "500"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("COMMISSION_RULES_FILE", path)
+	if _, err := CommissionRules(); err == nil {
+		t.Error("CommissionRules() with invalid JSON: expected an error")
+	}
+}
+
+func TestCommissionRuleFor(t *testing.T) {
+	counsellor := CommissionRule{Slabs: []CommissionSlab{{PerSale: 500}}}
+	fallback := CommissionRule{Slabs: []CommissionSlab{{PerSale: 300}}}
+
+	tests := []struct {
+		name    string
+		rules   map[string]CommissionRule
+		profile string
+		want    CommissionRule
+		wantOK  bool
+	}{
+		{"own rule", map[string]CommissionRule{"counsellor": counsellor, "*": fallback}, " Counsellor", counsellor, true},
+		{"fallback", map[string]CommissionRule{"counsellor": counsellor, "*": fallback}, "Manager", fallback, true},
+		{"no rule", map[string]CommissionRule{"counsellor": counsellor}, "Manager", CommissionRule{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CommissionRuleFor(tt.rules, tt.profile)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommissionRuleFor(%q) = %+v, %v; want %+v, %v", tt.profile, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// CallProviderConfig describes one dialer collection and how its documents map
+// onto a call record.
+type CallProviderConfig struct {
+	Name          string `json:"name"`
+	Collection    string `json:"collection"`
+	Match         string `json:"match"` // CallMatchEmployeeID or CallMatchName
+	StaffField    string `json:"staffField"`
+	PhoneField    string `json:"phoneField"`
+	TimeField     string `json:"timeField"`
+	DurationField string `json:"durationField"`
+}
+
+var defaultCallProviders = []CallProviderConfig{
+	{
+		Name:          "calllogs",
+		Collection:    "calllogs",
+		Match:         CallMatchEmployeeID,
+		StaffField:    "employeeId",
+		PhoneField:    "phoneNumber",
+		TimeField:     "timestamp",
+		DurationField: "duration",
+	},
+	{
+		Name:          "avyukta",
+		Collection:    "avyuktacalls",
+		Match:         CallMatchName,
+		StaffField:    "full_name",
+		PhoneField:    "phone_number",
+		TimeField:     "call_date",
+		DurationField: "lenth_in_sec",
+	},
+}
+
+// Provider staff matching strategies.
+const (
+	CallMatchEmployeeID = "employeeId"
+	CallMatchName       = "name"
+)
+
+// loadedCallProviders is set once at startup by LoadCallProviders.
+var loadedCallProviders []CallProviderConfig
+
+// LoadCallProviders reads and validates CALL_PROVIDERS_FILE. main calls it at
+// startup so a bad provider file stops the server instead of producing empty
+// report sections.
+func LoadCallProviders() error {
+	providers, err := readCallProviders(os.Getenv("CALL_PROVIDERS_FILE"))
+	if err != nil {
+		return err
+	}
+	loadedCallProviders = providers
+	return nil
+}
+
+// CallProviders returns the built-in calllogs and avyukta providers plus any
+// defined in the JSON file named by CALL_PROVIDERS_FILE. A file entry with a
+// built-in name replaces that provider. Example file:
+//
+//	[{"name": "knowlarity", "collection": "knowlaritycalls", "match": "employeeId",
+//	  "staffField": "agent_id", "phoneField": "customer_number",
+//	  "timeField": "start_time", "durationField": "call_duration"}]
+func CallProviders() []CallProviderConfig {
+	if loadedCallProviders != nil {
+		return append([]CallProviderConfig{}, loadedCallProviders...)
+	}
+
+	providers, err := readCallProviders(os.Getenv("CALL_PROVIDERS_FILE"))
+	if err != nil {
+		fmt.Println("Error loading call providers:", err)
+		return append([]CallProviderConfig{}, defaultCallProviders...)
+	}
+	return providers
+}
+
+// readCallProviders merges the provider file at path (if any) over the defaults.
+func readCallProviders(path string) ([]CallProviderConfig, error) {
+	providers := append([]CallProviderConfig{}, defaultCallProviders...)
+	if path == "" {
+		return providers, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	// Unknown keys are usually misspelt field names, so reject them
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var extra []CallProviderConfig
+	if err := decoder.Decode(&extra); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for i, p := range extra {
+		p, err := validateCallProvider(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s entry %d: %w", path, i+1, err)
+		}
+
+		replaced := false
+		for i := range providers {
+			if providers[i].Name == p.Name {
+				providers[i] = p
+				replaced = true
+			}
+		}
+		if !replaced {
+			providers = append(providers, p)
+		}
+	}
+
+	return providers, nil
+}
+
+// validateCallProvider normalises a provider entry and rejects one with a
+// missing field or an unknown match strategy.
+func validateCallProvider(p CallProviderConfig) (CallProviderConfig, error) {
+	p.Name = strings.ToLower(strings.TrimSpace(p.Name))
+
+	switch strings.ToLower(strings.TrimSpace(p.Match)) {
+	case strings.ToLower(CallMatchEmployeeID):
+		p.Match = CallMatchEmployeeID
+	case CallMatchName:
+		p.Match = CallMatchName
+	default:
+		return p, fmt.Errorf("provider %q: match must be %q or %q, got %q", p.Name, CallMatchEmployeeID, CallMatchName, p.Match)
+	}
+
+	var missing []string
+	for field, value := range map[string]string{
+		"name":          p.Name,
+		"collection":    p.Collection,
+		"staffField":    p.StaffField,
+		"phoneField":    p.PhoneField,
+		"timeField":     p.TimeField,
+		"durationField": p.DurationField,
+	} {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return p, fmt.Errorf("provider %q: missing %s", p.Name, strings.Join(missing, ", "))
+	}
+
+	return p, nil
+}
+
+// FindCallProvider returns the configured provider with the given name.
+func FindCallProvider(name string) (CallProviderConfig, bool) {
+	for _, p := range CallProviders() {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return CallProviderConfig{}, false
+}
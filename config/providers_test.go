@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateCallProvider(t *testing.T) {
+	valid := CallProviderConfig{
+		Name: " Knowlarity ", Collection: "knowlaritycalls", Match: "EMPLOYEEID",
+		StaffField: "agent_id", PhoneField: "customer_number", TimeField: "start_time", DurationField: "call_duration",
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(p *CallProviderConfig)
+		wantErr string
+	}{
+		{name: "valid", edit: func(p *CallProviderConfig) {}},
+		{name: "name match", edit: func(p *CallProviderConfig) { p.Match = "name" }},
+		{name: "missing match", edit: func(p *CallProviderConfig) { p.Match = "" }, wantErr: "match must be"},
+		{name: "unknown match", edit: func(p *CallProviderConfig) { p.Match = "email" }, wantErr: "match must be"},
+		{name: "missing phone field", edit: func(p *CallProviderConfig) { p.PhoneField = "" }, wantErr: "missing phoneField"},
+		{name: "missing duration field", edit: func(p *CallProviderConfig) { p.DurationField = " " }, wantErr: "missing durationField"},
+		{name: "several missing", edit: func(p *CallProviderConfig) { p.Collection, p.TimeField = "", "" }, wantErr: "missing collection, timeField"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.edit(&p)
+			got, err := validateCallProvider(p)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got.Name != "knowlarity" {
+					t.Errorf("Name = %q, want knowlarity", got.Name)
+				}
+				if got.Match != CallMatchEmployeeID && got.Match != CallMatchName {
+					t.Errorf("Match = %q not normalised", got.Match)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadCallProviders(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	defaults, err := readCallProviders("")
+	if err != nil || len(defaults) != 2 {
+		t.Fatalf("defaults = %v, %v", defaults, err)
+	}
+
+	override := write("override.json", `[
+		{"name": "avyukta", "collection": "avyukta2", "match": "name", "staffField": "agent",
+		 "phoneField": "phone", "timeField": "ts", "durationField": "secs"},
+		{"name": "knowlarity", "collection": "k", "match": "employeeId", "staffField": "a",
+		 "phoneField": "b", "timeField": "c", "durationField": "d"}
+	]`)
+	providers, err := readCallProviders(override)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(providers) != 3 || providers[1].Collection != "avyukta2" || providers[2].Name != "knowlarity" {
+		t.Errorf("providers = %+v", providers)
+	}
+
+	typo := write("typo.json", `[{"name": "x", "collection": "x", "match": "employeeId", "staffField": "a", "phoneFeild": "b", "timeField": "c", "durationField": "d"}]`)
+	if _, err := readCallProviders(typo); err == nil || !strings.Contains(err.Error(), "phoneFeild") {
+		t.Errorf("typo error = %v, want unknown field phoneFeild", err)
+	}
+
+	missing := write("missing-field.json", `[{"name": "x", "collection": "x", "match": "employeeId", "staffField": "a", "timeField": "c", "durationField": "d"}]`)
+	if _, err := readCallProviders(missing); err == nil || !strings.Contains(err.Error(), "missing phoneField") {
+		t.Errorf("missing field error = %v, want missing phoneField", err)
+	}
+
+	if _, err := readCallProviders(write("bad.json", `{`)); err == nil {
+		t.Error("expected a parse error")
+	}
+	if _, err := readCallProviders(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected a read error")
+	}
+}
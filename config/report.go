@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LeadAttributionMode returns how numbers shared by several lead sources are
+// attributed: "shared" (counted in every source), "exclusive" or "split".
+func LeadAttributionMode() string {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("LEAD_ATTRIBUTION_MODE")))
+	if mode == "" {
+		return "shared"
+	}
+	return mode
+}
+
+// LeadAttributionPriority returns the lead sources in priority order, highest first.
+func LeadAttributionPriority() []string {
+	raw := os.Getenv("LEAD_ATTRIBUTION_PRIORITY")
+	if raw == "" {
+		return []string{"advising", "crm", "client", "dialer"}
+	}
+
+	var priority []string
+	for _, source := range strings.Split(raw, ",") {
+		source = strings.ToLower(strings.TrimSpace(source))
+		if source != "" {
+			priority = append(priority, source)
+		}
+	}
+	return priority
+}
+
+// AvyuktaCSVLocation is the time zone of call_date values in Avyukta CSV
+// exports (AVYUKTA_CSV_TIMEZONE, default UTC).
+func AvyuktaCSVLocation() *time.Location {
+	name := os.Getenv("AVYUKTA_CSV_TIMEZONE")
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		fmt.Println("Invalid AVYUKTA_CSV_TIMEZONE, using UTC:", err)
+		return time.UTC
+	}
+	return loc
+}
+
+// DedupeCalls reports whether reports ignore duplicate call logs by default (CALL_DEDUPE).
+func DedupeCalls() bool {
+	value, _ := strconv.ParseBool(os.Getenv("CALL_DEDUPE"))
+	return value
+}
+
+// CallDuplicateWindow is how close two calls to the same number must be to
+// count as duplicates (CALL_DUPLICATE_WINDOW_SEC, default 5 seconds).
+func CallDuplicateWindow() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("CALL_DUPLICATE_WINDOW_SEC"))
+	if err != nil || seconds < 0 {
+		seconds = 5
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// TotalWindow is the default window for "total" metrics (TOTAL_WINDOW):
+// "all", "fytd", "year" or "rolling:<months>". Defaults to "all".
+func TotalWindow() string {
+	window := strings.ToLower(strings.TrimSpace(os.Getenv("TOTAL_WINDOW")))
+	if window == "" {
+		return "all"
+	}
+	return window
+}
+
+// FiscalYearStartMonth is the first month of the fiscal year
+// (FISCAL_YEAR_START_MONTH, 1-12, default 4 for April).
+func FiscalYearStartMonth() time.Month {
+	month, err := strconv.Atoi(os.Getenv("FISCAL_YEAR_START_MONTH"))
+	if err != nil || month < 1 || month > 12 {
+		return time.April
+	}
+	return time.Month(month)
+}
+
+// SalesCreditRule weights a sale for the person credited in L1 and in L2/L3.
+// With SplitNames, a field naming several people shares its weight equally.
+// When the weights sum to 1 or less they are shares of one sale, and a sale
+// with only L1 (or only L2/L3) filled gives that side both shares.
+type SalesCreditRule struct {
+	L1         float64 `json:"L1"`
+	L2L3       float64 `json:"L2L3"`
+	SplitNames bool    `json:"splitNames"`
+}
+
+// SalesCredit reads the split-credit rule from SALES_CREDIT_L1,
+// SALES_CREDIT_L2L3 (default 1 each) and SALES_CREDIT_SPLIT_NAMES.
+func SalesCredit() SalesCreditRule {
+	splitNames, _ := strconv.ParseBool(os.Getenv("SALES_CREDIT_SPLIT_NAMES"))
+	return SalesCreditRule{
+		L1:         creditWeight("SALES_CREDIT_L1"),
+		L2L3:       creditWeight("SALES_CREDIT_L2L3"),
+		SplitNames: splitNames,
+	}
+}
+
+func creditWeight(key string) float64 {
+	weight, err := strconv.ParseFloat(strings.TrimSpace(os.Getenv(key)), 64)
+	if err != nil || weight < 0 {
+		return 1
+	}
+	return weight
+}
+
+// SalesAmountField and SalesFeeField name the salesleads money fields
+// (SALES_AMOUNT_FIELD and SALES_FEE_FIELD, default "Amount" and "Fee").
+func SalesAmountField() string {
+	return envOr("SALES_AMOUNT_FIELD", "Amount")
+}
+
+func SalesFeeField() string {
+	return envOr("SALES_FEE_FIELD", "Fee")
+}
+
+func envOr(key, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// FollowUpDueDays is how many days after a client is assigned its follow-up
+// falls due (FOLLOW_UP_DUE_DAYS, default 2).
+func FollowUpDueDays() int {
+	days, err := strconv.Atoi(strings.TrimSpace(os.Getenv("FOLLOW_UP_DUE_DAYS")))
+	if err != nil || days < 0 {
+		return 2
+	}
+	return days
+}
+
+// FollowUpAssignedField is the clients field holding when a client was
+// assigned (FOLLOW_UP_ASSIGNED_FIELD, default "createdAt"). Clients without it
+// fall back to the creation time in their ObjectID.
+func FollowUpAssignedField() string {
+	return envOr("FOLLOW_UP_ASSIGNED_FIELD", "createdAt")
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ZoomTeamMap maps Zoom host/panelist emails or display names (lower-cased)
+// to attendees Team names. It is read from the JSON object in the file named
+// by ZOOM_TEAM_MAP_FILE, e.g. {"amit@example.com": "Amit Sharma"}. An unset
+// variable is an empty map; a file that cannot be read or parsed is an error.
+func ZoomTeamMap() (map[string]string, error) {
+	return readZoomTeamMap(os.Getenv("ZOOM_TEAM_MAP_FILE"))
+}
+
+func readZoomTeamMap(path string) (map[string]string, error) {
+	teams := map[string]string{}
+	if path == "" {
+		return teams, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading zoom team map: %w", err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing zoom team map %s: %w", path, err)
+	}
+
+	for key, team := range raw {
+		teams[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(team)
+	}
+	return teams, nil
+}
+
+// ZoomInterestedColumn is the participant report column (usually a poll
+// question) whose "yes" answers count as Intrested (ZOOM_INTERESTED_COLUMN).
+// Empty means the import leaves Intrested untouched.
+func ZoomInterestedColumn() string {
+	return strings.TrimSpace(os.Getenv("ZOOM_INTERESTED_COLUMN"))
+}
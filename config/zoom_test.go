@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadZoomTeamMap(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	teams, err := readZoomTeamMap("")
+	if err != nil || len(teams) != 0 {
+		t.Errorf("unset file = %v, %v; want an empty map", teams, err)
+	}
+
+	teams, err = readZoomTeamMap(write("teams.json", `{" Amit@Example.com ": " Amit Sharma "}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if teams["amit@example.com"] != "Amit Sharma" {
+		t.Errorf("teams = %v, want the key lower-cased and values trimmed", teams)
+	}
+
+	for name, path := range map[string]string{
+		"missing file": filepath.Join(dir, "missing.json"),
+		"invalid json": write("bad.json", `["amit"]`),
+	} {
+		if _, err := readZoomTeamMap(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
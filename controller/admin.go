@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"go_fiber_Zoom_Report/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAdmin lets a request through only when its X-Admin-Key header
+// matches ADMIN_API_KEY.
+func RequireAdmin(c *fiber.Ctx) error {
+	key := config.AdminAPIKey()
+	given := c.Get("X-Admin-Key")
+	if key == "" || subtle.ConstantTimeCompare([]byte(given), []byte(key)) != 1 {
+		return c.Status(403).JSON(fiber.Map{"error": "Admin access required"})
+	}
+	return c.Next()
+}
+
+// adminActor names who performed an admin action, from X-Admin-User if sent.
+func adminActor(c *fiber.Ctx) string {
+	if user := c.Get("X-Admin-User"); user != "" {
+		return user
+	}
+	return "admin"
+}
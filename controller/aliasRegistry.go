@@ -0,0 +1,289 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/models"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	aliasSourceAvyukta   = "avyukta"
+	aliasSourceAttendees = "attendees"
+	aliasSourceSales     = "sales"
+)
+
+var aliasSources = []string{aliasSourceAvyukta, aliasSourceAttendees, aliasSourceSales}
+
+// knownAliasSources adds every name-matched call provider from config to aliasSources.
+func knownAliasSources() []string {
+	sources := append([]string{}, aliasSources...)
+	for _, p := range config.CallProviders() {
+		if p.Match == config.CallMatchName && !containsString(sources, p.Name) {
+			sources = append(sources, p.Name)
+		}
+	}
+	return sources
+}
+
+// aliasRegistry maps employeeId -> source -> known name variants.
+type aliasRegistry map[string]map[string][]string
+
+// UnmatchedName is a name found in a name-joined collection that no staff identity resolves to.
+type UnmatchedName struct {
+	Source      string `json:"source"`
+	Name        string `json:"name"`
+	Occurrences int    `json:"occurrences"`
+}
+
+// loadAliasRegistry reads every staffaliases document. A failed read leaves the
+// registry empty so reports fall back to the staff name alone.
+func loadAliasRegistry() aliasRegistry {
+	collection := config.GetCollection("ZoomDB", "staffaliases")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	registry := aliasRegistry{}
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		fmt.Println("Error fetching aliases:", err)
+		return registry
+	}
+	defer cursor.Close(ctx)
+
+	var aliases []models.StaffAlias
+	if err := cursor.All(ctx, &aliases); err != nil {
+		fmt.Println("Decode error:", err)
+		return registry
+	}
+
+	for _, a := range aliases {
+		if registry[a.EmployeeID] == nil {
+			registry[a.EmployeeID] = map[string][]string{}
+		}
+		registry[a.EmployeeID][a.Source] = append(registry[a.EmployeeID][a.Source], a.Names...)
+	}
+
+	return registry
+}
+
+// names returns the staff name followed by every alias registered for source.
+func (r aliasRegistry) names(s models.Staff, source string) []string {
+	names := []string{s.Name}
+	for _, alias := range r[s.EmployeeID][source] {
+		alias = strings.TrimSpace(alias)
+		if alias != "" {
+			names = append(names, alias)
+		}
+	}
+	return removeDuplicates(names)
+}
+
+// resolver returns a lookup from normalised name to employeeId for one source.
+func (r aliasRegistry) resolver(staffList []models.Staff, source string) map[string]string {
+	lookup := map[string]string{}
+	for _, s := range staffList {
+		for _, name := range r.names(s, source) {
+			lookup[normalizeName(name)] = s.EmployeeID
+		}
+	}
+	return lookup
+}
+
+// normalizeName lower-cases a name and collapses its whitespace.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// nameMatchers turns names into anchored, case-insensitive patterns for an $in
+// filter, so stored names match the way normalizeName compares them.
+func nameMatchers(names []string) bson.A {
+	matchers := bson.A{}
+	for _, name := range removeDuplicates(names) {
+		words := strings.Fields(name)
+		if len(words) == 0 {
+			continue
+		}
+		for i, word := range words {
+			words[i] = regexp.QuoteMeta(word)
+		}
+		matchers = append(matchers, primitive.Regex{
+			Pattern: `^\s*` + strings.Join(words, `\s+`) + `\s*$`,
+			Options: "i",
+		})
+	}
+	return matchers
+}
+
+// GetStaffAliases lists the alias registry, optionally for one employeeId.
+func GetStaffAliases(c *fiber.Ctx) error {
+	collection := config.GetCollection("ZoomDB", "staffaliases")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if empID := c.Query("employeeId"); empID != "" {
+		filter["employeeId"] = empID
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "employeeId", Value: 1}, {Key: "source", Value: 1}}))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch aliases"})
+	}
+	defer cursor.Close(ctx)
+
+	aliases := []models.StaffAlias{}
+	if err := cursor.All(ctx, &aliases); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode aliases"})
+	}
+
+	return c.JSON(aliases)
+}
+
+// UpsertStaffAlias replaces the name variants of one employee for one source.
+func UpsertStaffAlias(c *fiber.Ctx) error {
+	var alias models.StaffAlias
+	if err := c.BodyParser(&alias); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid JSON"})
+	}
+
+	alias.EmployeeID = strings.TrimSpace(alias.EmployeeID)
+	alias.Source = strings.ToLower(strings.TrimSpace(alias.Source))
+	if alias.EmployeeID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "employeeId is required"})
+	}
+	if !containsString(knownAliasSources(), alias.Source) {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid source. Use " + strings.Join(knownAliasSources(), ", ")})
+	}
+
+	var names []string
+	for _, name := range alias.Names {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	alias.Names = removeDuplicates(names)
+	alias.UpdatedAt = time.Now().UTC()
+
+	collection := config.GetCollection("ZoomDB", "staffaliases")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"employeeId": alias.EmployeeID, "source": alias.Source},
+		bson.M{"$set": bson.M{"names": alias.Names, "updatedAt": alias.UpdatedAt}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save alias"})
+	}
+
+	return c.JSON(fiber.Map{"message": "Alias saved", "alias": alias})
+}
+
+// GetUnmatchedStaffNames lists names in attendees, salesleads and every
+// name-matched call provider that do not resolve to any reported staff member or alias.
+func GetUnmatchedStaffNames(c *fiber.Ctx) error {
+	staffList, err := fetchReportStaff(bson.M{})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	registry := loadAliasRegistry()
+
+	found := map[string]map[string]int{
+		aliasSourceAttendees: distinctNameCounts("attendees", "$Team"),
+		aliasSourceSales:     salesNameCounts(),
+	}
+	for _, p := range config.CallProviders() {
+		if p.Match == config.CallMatchName {
+			found[p.Name] = distinctNameCounts(p.Collection, "$"+p.StaffField)
+		}
+	}
+
+	unmatched := []UnmatchedName{}
+	for _, source := range knownAliasSources() {
+		lookup := registry.resolver(staffList, source)
+		for name, count := range found[source] {
+			if _, ok := lookup[normalizeName(name)]; ok {
+				continue
+			}
+			unmatched = append(unmatched, UnmatchedName{Source: source, Name: name, Occurrences: count})
+		}
+	}
+
+	sort.Slice(unmatched, func(i, j int) bool {
+		if unmatched[i].Source == unmatched[j].Source {
+			return unmatched[i].Occurrences > unmatched[j].Occurrences
+		}
+		return unmatched[i].Source < unmatched[j].Source
+	})
+
+	return c.JSON(unmatched)
+}
+
+// distinctNameCounts groups a collection by a name field and counts documents per name.
+func distinctNameCounts(collectionName, field string) map[string]int {
+	collection := config.GetCollection("ZoomDB", collectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := bson.A{
+		bson.M{"$group": bson.M{"_id": field, "count": bson.M{"$sum": 1}}},
+	}
+
+	counts := map[string]int{}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		fmt.Println("Aggregation error:", err)
+		return counts
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Name  interface{} `bson:"_id"`
+		Count int         `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		fmt.Println("Cursor decode error:", err)
+		return counts
+	}
+
+	for _, r := range results {
+		name, ok := r.Name.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		counts[strings.TrimSpace(name)] += r.Count
+	}
+
+	return counts
+}
+
+// salesNameCounts counts the staff names credited in L1 and L2/L3 of salesleads.
+func salesNameCounts() map[string]int {
+	counts := map[string]int{}
+	for _, field := range []string{"$L1", "$L2/L3"} {
+		for value, count := range distinctNameCounts("salesleads", field) {
+			for _, name := range splitSalesNames(value) {
+				counts[name] += count
+			}
+		}
+	}
+	return counts
+}
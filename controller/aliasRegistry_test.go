@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/models"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAliasRegistryNames(t *testing.T) {
+	registry := aliasRegistry{
+		"E1": {
+			aliasSourceAvyukta: {" Amit K ", "", "Amit Kumar"},
+		},
+	}
+	staff := models.Staff{EmployeeID: "E1", Name: "Amit Kumar"}
+
+	tests := []struct {
+		source string
+		want   []string
+	}{
+		{aliasSourceAvyukta, []string{"Amit Kumar", "Amit K"}},
+		{aliasSourceSales, []string{"Amit Kumar"}},
+	}
+
+	for _, tt := range tests {
+		if got := registry.names(staff, tt.source); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("names(%s) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestAliasRegistryResolver(t *testing.T) {
+	registry := aliasRegistry{"E2": {aliasSourceAttendees: {"PRIYA  team"}}}
+	staffList := []models.Staff{
+		{EmployeeID: "E1", Name: "Amit Kumar"},
+		{EmployeeID: "E2", Name: "Priya"},
+	}
+
+	lookup := registry.resolver(staffList, aliasSourceAttendees)
+	want := map[string]string{"amit kumar": "E1", "priya": "E2", "priya team": "E2"}
+	if !reflect.DeepEqual(lookup, want) {
+		t.Errorf("resolver() = %v, want %v", lookup, want)
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"Amit Kumar":       "amit kumar",
+		"  AMIT \t Kumar ": "amit kumar",
+		"":                 "",
+	}
+	for in, want := range tests {
+		if got := normalizeName(in); got != want {
+			t.Errorf("normalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestNameMatchers checks the $in patterns accept exactly what normalizeName
+// treats as the same name, so reports and /aliases/unmatched agree.
+func TestNameMatchers(t *testing.T) {
+	matchers := nameMatchers([]string{"Amit Kumar", "A.K. (Sales)", " ", "Amit Kumar"})
+	if len(matchers) != 2 {
+		t.Fatalf("len(matchers) = %d, want 2", len(matchers))
+	}
+
+	tests := []struct {
+		stored string
+		want   bool
+	}{
+		{"Amit Kumar", true},
+		{"amit   KUMAR ", true},
+		{"Amit Kumari", false},
+		{"Sumit Kumar", false},
+		{"a.k. (sales)", true},
+		{"AxK. (Sales)", false},
+	}
+
+	for _, tt := range tests {
+		matched := false
+		for _, m := range matchers {
+			re := regexp.MustCompile("(?" + m.(primitive.Regex).Options + ")" + m.(primitive.Regex).Pattern)
+			if re.MatchString(tt.stored) {
+				matched = true
+			}
+		}
+		if matched != tt.want {
+			t.Errorf("stored name %q matched = %v, want %v", tt.stored, matched, tt.want)
+		}
+		if matched && normalizeName(tt.stored) != normalizeName("Amit Kumar") && normalizeName(tt.stored) != normalizeName("A.K. (Sales)") {
+			t.Errorf("%q matched but does not normalise to a registered name", tt.stored)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AttendeeMetrics is every attendees figure for a staff member: the range and
+// lifetime sums, plus optional per-session rows.
+type AttendeeMetrics struct {
+	Attendees         int               `json:"attendees"`
+	TotalAttendees    int               `json:"totalAttendees"`
+	Intrested         int               `json:"intrested"`
+	TotalIntrested    int               `json:"totalIntrested"`
+	Registration      int               `json:"registration"`
+	TotalRegistration int               `json:"totalRegistration"`
+	Sessions          []AttendeeSession `json:"sessions,omitempty"`
+}
+
+// AttendeeSession is one webinar (one attendees Date) within the range.
+type AttendeeSession struct {
+	Date         string `json:"date"`
+	Attendees    int    `json:"attendees"`
+	Intrested    int    `json:"intrested"`
+	Registration int    `json:"registration"`
+}
+
+type attendeeSums struct {
+	Date         time.Time `bson:"_id"`
+	Attendees    float64   `bson:"attendees"`
+	Intrested    float64   `bson:"intrested"`
+	Registration float64   `bson:"registration"`
+}
+
+// attendeeSumFields sums the three counters of the attendees collection.
+var attendeeSumFields = bson.M{
+	"attendees":    bson.M{"$sum": "$Attendees"},
+	"intrested":    bson.M{"$sum": "$Intrested"},
+	"registration": bson.M{"$sum": "$Registration"},
+}
+
+func attendeeGroup(id interface{}) bson.M {
+	group := bson.M{"_id": id}
+	for key, value := range attendeeSumFields {
+		group[key] = value
+	}
+	return bson.M{"$group": group}
+}
+
+// getAttendeeMetrics computes range and total Attendees, Intrested and
+// Registration for the given Team names in one $facet query. The totals cover
+// the total window, which is all time unless configured otherwise.
+func getAttendeeMetrics(teams []string, start, end time.Time, total utils.TotalWindow, withSessions bool) AttendeeMetrics {
+	collection := config.GetCollection("ZoomDB", "attendees")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	inRange := bson.M{"$match": bson.M{"Date": bson.M{"$gte": start, "$lte": end}}}
+
+	totalStages := bson.A{attendeeGroup(nil)}
+	if total.Bounded() {
+		inTotal := bson.M{"$match": bson.M{"Date": bson.M{"$gte": total.Start, "$lte": total.End}}}
+		totalStages = bson.A{inTotal, attendeeGroup(nil)}
+	}
+
+	facets := bson.M{
+		"range": bson.A{inRange, attendeeGroup(nil)},
+		"total": totalStages,
+	}
+	if withSessions {
+		facets["sessions"] = bson.A{inRange, attendeeGroup("$Date"), bson.M{"$sort": bson.M{"_id": 1}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"Team": bson.M{"$in": nameMatchers(teams)}}}},
+		{{Key: "$facet", Value: facets}},
+	}
+
+	var metrics AttendeeMetrics
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		fmt.Println("Aggregation error:", err)
+		return metrics
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Range    []attendeeSums `bson:"range"`
+		Total    []attendeeSums `bson:"total"`
+		Sessions []attendeeSums `bson:"sessions"`
+	}
+	if err := cursor.All(ctx, &results); err != nil || len(results) == 0 {
+		fmt.Println("Cursor decode error:", err)
+		return metrics
+	}
+
+	if len(results[0].Range) > 0 {
+		r := results[0].Range[0]
+		metrics.Attendees, metrics.Intrested, metrics.Registration = int(r.Attendees), int(r.Intrested), int(r.Registration)
+	}
+	if len(results[0].Total) > 0 {
+		t := results[0].Total[0]
+		metrics.TotalAttendees, metrics.TotalIntrested, metrics.TotalRegistration = int(t.Attendees), int(t.Intrested), int(t.Registration)
+	}
+	if withSessions {
+		metrics.Sessions = []AttendeeSession{}
+		for _, s := range results[0].Sessions {
+			metrics.Sessions = append(metrics.Sessions, AttendeeSession{
+				Date:         s.Date.UTC().Format("02-01-2006"),
+				Attendees:    int(s.Attendees),
+				Intrested:    int(s.Intrested),
+				Registration: int(s.Registration),
+			})
+		}
+	}
+
+	return metrics
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAttendeeGroup(t *testing.T) {
+	tests := []struct {
+		name string
+		id   interface{}
+	}{
+		{"overall", nil},
+		{"per session", "$Date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := bson.M{"$group": bson.M{
+				"_id":          tt.id,
+				"attendees":    bson.M{"$sum": "$Attendees"},
+				"intrested":    bson.M{"$sum": "$Intrested"},
+				"registration": bson.M{"$sum": "$Registration"},
+			}}
+			if got := attendeeGroup(tt.id); !reflect.DeepEqual(got, want) {
+				t.Errorf("attendeeGroup(%v) = %v, want %v", tt.id, got, want)
+			}
+		})
+	}
+
+	// Building a group must not write into the shared sum fields.
+	attendeeGroup("$Date")
+	if _, ok := attendeeSumFields["_id"]; ok {
+		t.Error("attendeeGroup modified attendeeSumFields")
+	}
+}
@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// avyuktaColumnAliases maps normalised vendor CSV headers onto the schema
+// fields they fill. The vendor portal has used both spellings of length.
+var avyuktaColumnAliases = map[string][]string{
+	"staff":    {"full_name", "agent_name", "user_name", "agent"},
+	"time":     {"call_date", "call_time", "date"},
+	"duration": {"lenth_in_sec", "length_in_sec", "length", "duration", "talk_sec"},
+	"phone":    {"phone_number", "phone", "number", "customer_number"},
+	"uniqueid": {"uniqueid", "unique_id", "call_id"},
+}
+
+// UploadResult summarises one CSV upload.
+type UploadResult struct {
+	Rows     int              `json:"rows"`
+	Inserted int              `json:"inserted"`
+	Updated  int              `json:"updated"`
+	Rejected int              `json:"rejected"`
+	Errors   []IngestRowError `json:"errors"`
+}
+
+// UploadAvyuktaCSV loads a vendor portal CSV export (multipart field "file")
+// into avyuktacalls. Rows are upserted on uniqueid, falling back to staff
+// name, call time and length, so uploading the same file twice (or a file
+// whose calls were loaded by hand) does not double talk time.
+func UploadAvyuktaCSV(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "CSV file is required in field \"file\""})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Failed to read uploaded file"})
+	}
+	defer file.Close()
+
+	provider, _ := config.FindCallProvider(providerAvyukta)
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "CSV file is empty or unreadable"})
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[normalizeCSVHeader(name)] = i
+	}
+
+	index := map[string]int{}
+	for field, aliases := range avyuktaColumnAliases {
+		for _, alias := range aliases {
+			if i, ok := columns[alias]; ok {
+				index[field] = i
+				break
+			}
+		}
+	}
+	for _, required := range []string{"staff", "time", "duration"} {
+		if _, ok := index[required]; !ok {
+			return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("CSV is missing a %s column", strings.Join(avyuktaColumnAliases[required], "/"))})
+		}
+	}
+
+	loc := config.AvyuktaCSVLocation()
+	result := UploadResult{Errors: []IngestRowError{}}
+
+	var writes []mongo.WriteModel
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		result.Rows++
+		if err != nil {
+			result.Errors = append(result.Errors, IngestRowError{Row: line, Error: err.Error()})
+			continue
+		}
+
+		model, err := avyuktaRowModel(provider, header, record, index, loc)
+		if err != nil {
+			result.Errors = append(result.Errors, IngestRowError{Row: line, Error: err.Error()})
+			continue
+		}
+		writes = append(writes, model)
+	}
+	result.Rejected = len(result.Errors)
+
+	if len(writes) == 0 {
+		return c.JSON(result)
+	}
+
+	collection := config.GetCollection("ZoomDB", provider.Collection)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	written, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if written != nil {
+		result.Inserted = int(written.UpsertedCount)
+		result.Updated = int(written.MatchedCount)
+	}
+	if err != nil {
+		fmt.Println("Error writing avyukta calls:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save calls", "result": result})
+	}
+
+	return c.JSON(result)
+}
+
+// avyuktaRowModel validates one CSV row and builds its idempotent upsert.
+func avyuktaRowModel(provider config.CallProviderConfig, header, record []string, index map[string]int, loc *time.Location) (mongo.WriteModel, error) {
+	cell := func(field string) string {
+		i, ok := index[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	name := cell("staff")
+	if name == "" {
+		return nil, fmt.Errorf("full_name is empty")
+	}
+
+	callDate := parseCallTime(cell("time"), loc)
+	if callDate.IsZero() {
+		return nil, fmt.Errorf("invalid call_date %q", cell("time"))
+	}
+
+	duration, err := parseDurationSeconds(cell("duration"))
+	if err != nil {
+		return nil, err
+	}
+
+	phone := normalizePhoneNumber(cell("phone"))
+
+	// Keep every vendor column, then overwrite the schema fields with parsed values
+	doc := bson.M{}
+	for i, name := range header {
+		if i < len(record) {
+			doc[normalizeCSVHeader(name)] = strings.TrimSpace(record[i])
+		}
+	}
+	doc[provider.StaffField] = name
+	doc[provider.TimeField] = callDate
+	doc[provider.DurationField] = duration
+	if provider.PhoneField != "" {
+		doc[provider.PhoneField] = phone
+	}
+
+	// Rows loaded before uploads existed have no uniqueid, so they are matched
+	// on staff, call time and length (stored as a number or as text) and on the
+	// phone, as uploaded or normalised. Only stored rows without a phone match
+	// on the rest alone, so same-second dials to different numbers stay apart.
+	legacy := bson.M{
+		provider.StaffField:    name,
+		provider.TimeField:     callDate,
+		provider.DurationField: bson.M{"$in": bson.A{duration, strconv.Itoa(duration)}},
+	}
+	if provider.PhoneField != "" && phone != "" {
+		phones := bson.A{phone, nil, ""}
+		if raw := cell("phone"); raw != phone {
+			phones = append(phones, raw)
+		}
+		legacy[provider.PhoneField] = bson.M{"$in": phones}
+	}
+	filter := legacy
+	if uniqueID := cell("uniqueid"); uniqueID != "" {
+		legacy["uniqueid"] = bson.M{"$exists": false}
+		filter = bson.M{"$or": bson.A{bson.M{"uniqueid": uniqueID}, legacy}}
+		doc["uniqueid"] = uniqueID
+	}
+
+	return mongo.NewUpdateOneModel().
+		SetFilter(filter).
+		SetUpdate(bson.M{"$set": doc}).
+		SetUpsert(true), nil
+}
+
+// parseDurationSeconds reads a call length given as seconds (possibly
+// fractional, e.g. "0.4") or as "mm:ss" / "hh:mm:ss". Blank means 0.
+func parseDurationSeconds(raw string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+
+	if strings.Contains(raw, ":") {
+		seconds := 0
+		for _, part := range strings.Split(raw, ":") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid length %q", raw)
+			}
+			seconds = seconds*60 + n
+		}
+		return seconds, nil
+	}
+
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("invalid length %q", raw)
+	}
+	if f < 0 {
+		return 0, fmt.Errorf("length cannot be negative")
+	}
+	return int(math.Round(f)), nil
+}
+
+// normalizeCSVHeader lower-cases a header and joins its words with "_".
+func normalizeCSVHeader(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("-", " ", ".", " ", "(", " ", ")", " ").Replace(name)
+	return strings.Join(strings.Fields(name), "_")
+}
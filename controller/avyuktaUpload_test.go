@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/config"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestParseDurationSeconds(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"0.4", 0, false},
+		{"0.6", 1, false},
+		{"45", 45, false},
+		{" 12.5 ", 13, false},
+		{"00:00", 0, false},
+		{"02:05", 125, false},
+		{"1:00:01", 3601, false},
+		{"-3", 0, true},
+		{"abc", 0, true},
+		{"1:xx", 0, true},
+		{"NaN", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDurationSeconds(tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseDurationSeconds(%q) = %d, %v; want %d, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNormalizeCSVHeader(t *testing.T) {
+	tests := map[string]string{
+		"\ufeffFull Name": "full_name",
+		" Lenth-In-Sec ":  "lenth_in_sec",
+		"Call Date (IST)": "call_date_ist",
+		"phone.number":    "phone_number",
+		"uniqueid":        "uniqueid",
+	}
+	for in, want := range tests {
+		if got := normalizeCSVHeader(in); got != want {
+			t.Errorf("normalizeCSVHeader(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAvyuktaRowModel(t *testing.T) {
+	provider := config.CallProviderConfig{
+		Name: "avyukta", Collection: "avyuktacalls", Match: config.CallMatchName,
+		StaffField: "full_name", PhoneField: "phone_number", TimeField: "call_date", DurationField: "lenth_in_sec",
+	}
+	header := []string{"full_name", "call_date", "lenth_in_sec", "phone_number", "uniqueid"}
+	index := map[string]int{"staff": 0, "time": 1, "duration": 2, "phone": 3, "uniqueid": 4}
+	callDate := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("uniqueid with legacy fallback", func(t *testing.T) {
+		model, err := avyuktaRowModel(provider, header, []string{"Amit", "2025-01-02 10:00:00", "0.4", "+91 98765 43210", "U1"}, index, time.UTC)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		update := model.(*mongo.UpdateOneModel)
+		filter := update.Filter.(bson.M)
+		or, ok := filter["$or"].(bson.A)
+		if !ok || len(or) != 2 {
+			t.Fatalf("filter = %v, want $or of uniqueid and legacy key", filter)
+		}
+		if or[0].(bson.M)["uniqueid"] != "U1" {
+			t.Errorf("first clause = %v", or[0])
+		}
+		legacy := or[1].(bson.M)
+		if legacy["full_name"] != "Amit" || !legacy["call_date"].(time.Time).Equal(callDate) || legacy["uniqueid"] == nil {
+			t.Errorf("legacy clause = %v", legacy)
+		}
+		wantPhones := bson.A{"919876543210", nil, "", "+91 98765 43210"}
+		if phones := legacy["phone_number"].(bson.M)["$in"]; !reflect.DeepEqual(phones, wantPhones) {
+			t.Errorf("legacy phone match = %v, want %v", phones, wantPhones)
+		}
+		set := update.Update.(bson.M)["$set"].(bson.M)
+		if set["lenth_in_sec"] != 0 || set["phone_number"] != "919876543210" {
+			t.Errorf("$set = %v", set)
+		}
+	})
+
+	t.Run("no uniqueid", func(t *testing.T) {
+		model, err := avyuktaRowModel(provider, header, []string{"Amit", "2025-01-02 10:00:00", "65", "9876543210", ""}, index, time.UTC)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		filter := model.(*mongo.UpdateOneModel).Filter.(bson.M)
+		if _, ok := filter["$or"]; ok {
+			t.Errorf("filter = %v, want the legacy key only", filter)
+		}
+		if in := filter["lenth_in_sec"].(bson.M)["$in"].(bson.A); in[0] != 65 || in[1] != "65" {
+			t.Errorf("length match = %v", in)
+		}
+		if phones := filter["phone_number"].(bson.M)["$in"]; !reflect.DeepEqual(phones, bson.A{"9876543210", nil, ""}) {
+			t.Errorf("phone match = %v", phones)
+		}
+	})
+
+	t.Run("same second, different numbers", func(t *testing.T) {
+		first, _ := avyuktaRowModel(provider, header, []string{"Amit", "2025-01-02 10:00:00", "0", "9876543210", ""}, index, time.UTC)
+		second, _ := avyuktaRowModel(provider, header, []string{"Amit", "2025-01-02 10:00:00", "0", "9123456780", ""}, index, time.UTC)
+		if reflect.DeepEqual(first.(*mongo.UpdateOneModel).Filter, second.(*mongo.UpdateOneModel).Filter) {
+			t.Error("calls to different numbers share one upsert filter")
+		}
+	})
+
+	t.Run("no phone", func(t *testing.T) {
+		model, err := avyuktaRowModel(provider, header, []string{"Amit", "2025-01-02 10:00:00", "5", "", ""}, index, time.UTC)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := model.(*mongo.UpdateOneModel).Filter.(bson.M)["phone_number"]; ok {
+			t.Errorf("filter = %v, want no phone match", model.(*mongo.UpdateOneModel).Filter)
+		}
+	})
+
+	errorCases := map[string][]string{
+		"full_name is empty": {"", "2025-01-02 10:00:00", "5", "", ""},
+		"invalid call_date":  {"Amit", "later", "5", "", ""},
+		"invalid length":     {"Amit", "2025-01-02 10:00:00", "five", "", ""},
+	}
+	for want, record := range errorCases {
+		if _, err := avyuktaRowModel(provider, header, record, index, time.UTC); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// phoneNumberPattern accepts 7–15 digits once the number is normalised.
+var phoneNumberPattern = regexp.MustCompile(`^[0-9]{7,15}$`)
+
+// phoneNumberSeparators are stripped from incoming numbers before validation.
+// The leading "+" goes too: lead lists store numbers as plain digits (often
+// numerically), and reports join calllogs to them by exact value.
+var phoneNumberSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "", "+", "")
+
+// IngestRowError reports why one row of a batch was not inserted.
+type IngestRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// IngestResult summarises one ingestion batch.
+type IngestResult struct {
+	Received   int              `json:"received"`
+	Inserted   int              `json:"inserted"`
+	Duplicates int              `json:"duplicates"`
+	Rejected   int              `json:"rejected"`
+	Errors     []IngestRowError `json:"errors"`
+}
+
+type callLogRow struct {
+	row         int
+	employeeID  string
+	phoneNumber string
+	timestamp   time.Time
+	doc         bson.M
+}
+
+func (r callLogRow) key() string {
+	return callLogKey(r.employeeID, r.phoneNumber, r.timestamp)
+}
+
+// callLogKey is the (employeeId, phoneNumber, timestamp) identity of a call log.
+// Timestamps are compared at millisecond precision, as stored by MongoDB.
+func callLogKey(employeeID, phoneNumber string, timestamp time.Time) string {
+	return employeeID + "|" + phoneNumber + "|" + strconv.FormatInt(timestamp.UnixMilli(), 10)
+}
+
+// IngestCallLogs accepts a batch of call records as a JSON array or NDJSON
+// (Content-Type application/x-ndjson), validates and normalises each row and
+// inserts the ones not already in calllogs. With ?strict=true a single bad
+// row rejects the whole batch.
+func IngestCallLogs(c *fiber.Ctx) error {
+	rawRows, err := parseIngestBody(c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if len(rawRows) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "No call records in request body"})
+	}
+
+	result := IngestResult{Received: len(rawRows), Errors: []IngestRowError{}}
+
+	var rows []callLogRow
+	for i, raw := range rawRows {
+		row, err := normalizeCallLogRow(i+1, raw)
+		if err != nil {
+			result.Errors = append(result.Errors, IngestRowError{Row: i + 1, Error: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	rows, err = rejectUnknownEmployees(rows, &result)
+	if err != nil {
+		fmt.Println("Error checking employees:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check employeeIds"})
+	}
+	result.Rejected = len(result.Errors)
+
+	if c.QueryBool("strict") && result.Rejected > 0 {
+		return c.Status(400).JSON(result)
+	}
+
+	rows, result.Duplicates, err = dropDuplicateCallLogs(rows)
+	if err != nil {
+		fmt.Println("Error checking duplicates:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check for duplicate call logs"})
+	}
+	if len(rows) == 0 {
+		return c.JSON(result)
+	}
+
+	collection := config.GetCollection("ZoomDB", "calllogs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ingestedAt := time.Now().UTC()
+	docs := make([]interface{}, 0, len(rows))
+	for _, r := range rows {
+		r.doc["ingestedAt"] = ingestedAt
+		docs = append(docs, r.doc)
+	}
+
+	inserted, err := collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if inserted != nil {
+		result.Inserted = len(inserted.InsertedIDs)
+	}
+	// A concurrent batch may have stored the same calls since the check; the
+	// unique index rejects them and they count as duplicates
+	if n, onlyDuplicates := duplicateKeyErrors(err); onlyDuplicates {
+		result.Duplicates += n
+		result.Inserted = len(docs) - n
+		err = nil
+	}
+	if err != nil {
+		fmt.Println("Error inserting call logs:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to insert call logs", "result": result})
+	}
+
+	return c.JSON(result)
+}
+
+// parseIngestBody decodes a JSON array, a {"records": [...]} object or NDJSON.
+func parseIngestBody(contentType string, body []byte) ([]map[string]interface{}, error) {
+	body = bytes.TrimSpace(body)
+
+	if strings.Contains(contentType, "ndjson") || strings.Contains(contentType, "jsonlines") {
+		var rows []map[string]interface{}
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			var row map[string]interface{}
+			if err := json.Unmarshal(text, &row); err != nil {
+				return nil, fmt.Errorf("invalid NDJSON on line %d", line)
+			}
+			rows = append(rows, row)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("invalid NDJSON: %v", err)
+		}
+		return rows, nil
+	}
+
+	if len(body) > 0 && body[0] == '{' {
+		var wrapped struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		if err := json.Unmarshal(body, &wrapped); err != nil {
+			return nil, fmt.Errorf("invalid JSON")
+		}
+		return wrapped.Records, nil
+	}
+
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, fmt.Errorf("invalid JSON")
+	}
+	return rows, nil
+}
+
+// normalizeCallLogRow validates the required fields and builds the calllogs
+// document. Duration is stored as a string of whole seconds like existing logs.
+func normalizeCallLogRow(index int, raw map[string]interface{}) (callLogRow, error) {
+	employeeID := strings.TrimSpace(jsonString(raw["employeeId"]))
+	if employeeID == "" {
+		return callLogRow{}, fmt.Errorf("employeeId is required")
+	}
+
+	phone := normalizePhoneNumber(jsonString(raw["phoneNumber"]))
+	if !phoneNumberPattern.MatchString(phone) {
+		return callLogRow{}, fmt.Errorf("invalid phoneNumber %q", jsonString(raw["phoneNumber"]))
+	}
+
+	timestamp := anyToTime(raw["timestamp"])
+	if timestamp.IsZero() {
+		return callLogRow{}, fmt.Errorf("invalid or missing timestamp")
+	}
+	if timestamp.After(time.Now().UTC().Add(24 * time.Hour)) {
+		return callLogRow{}, fmt.Errorf("timestamp is in the future")
+	}
+	timestamp = timestamp.Truncate(time.Millisecond)
+
+	duration := 0
+	if val, ok := raw["duration"]; ok && val != nil {
+		duration = anyToSeconds(val)
+		if duration < 0 {
+			return callLogRow{}, fmt.Errorf("duration cannot be negative")
+		}
+	}
+
+	doc := bson.M{}
+	for key, value := range raw {
+		if key != "_id" {
+			doc[key] = value
+		}
+	}
+	doc["employeeId"] = employeeID
+	doc["phoneNumber"] = phone
+	doc["timestamp"] = timestamp
+	doc["duration"] = strconv.Itoa(duration)
+
+	return callLogRow{
+		row:         index,
+		employeeID:  employeeID,
+		phoneNumber: phone,
+		timestamp:   timestamp,
+		doc:         doc,
+	}, nil
+}
+
+// normalizePhoneNumber strips separators and "+" so the number has the plain
+// digit form used by the lead lists.
+func normalizePhoneNumber(raw string) string {
+	return phoneNumberSeparators.Replace(strings.TrimSpace(raw))
+}
+
+// jsonString reads a JSON scalar as a string; numbers keep their integer form.
+func jsonString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
+
+// rejectUnknownEmployees drops rows whose employeeId is not in staffs. An
+// error means the check could not run, and nothing should be inserted.
+func rejectUnknownEmployees(rows []callLogRow, result *IngestResult) ([]callLogRow, error) {
+	if len(rows) == 0 {
+		return rows, nil
+	}
+
+	var ids []string
+	for _, r := range rows {
+		ids = append(ids, r.employeeID)
+	}
+
+	staffCollection := config.GetCollection("ZoomDB", "staffs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	known, err := staffCollection.Distinct(ctx, "employeeId", bson.M{"employeeId": bson.M{"$in": removeDuplicates(ids)}})
+	if err != nil {
+		return nil, err
+	}
+
+	knownIDs := map[string]bool{}
+	for _, id := range known {
+		knownIDs[leadNumberString(id)] = true
+	}
+
+	var valid []callLogRow
+	for _, r := range rows {
+		if !knownIDs[r.employeeID] {
+			result.Errors = append(result.Errors, IngestRowError{Row: r.row, Error: fmt.Sprintf("unknown employeeId %q", r.employeeID)})
+			continue
+		}
+		valid = append(valid, r)
+	}
+	return valid, nil
+}
+
+// dropDuplicateCallLogs removes rows repeated within the batch or already stored.
+func dropDuplicateCallLogs(rows []callLogRow) ([]callLogRow, int, error) {
+	if len(rows) == 0 {
+		return rows, 0, nil
+	}
+
+	existing, err := existingCallLogKeys(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	unique, duplicates := dedupeCallLogRows(rows, existing)
+	return unique, duplicates, nil
+}
+
+// duplicateKeyErrors counts the duplicate key errors of an unordered insert and
+// reports whether they were its only errors.
+func duplicateKeyErrors(err error) (int, bool) {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return 0, false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(writeErr) {
+			return 0, false
+		}
+	}
+	return len(bulkErr.WriteErrors), true
+}
+
+// EnsureCallLogIndex makes ingested call logs unique on employeeId,
+// phoneNumber and timestamp, so concurrent batches cannot both insert a call.
+// It covers rows with ingestedAt only, as older logs were never deduplicated.
+func EnsureCallLogIndex() error {
+	collection := config.GetCollection("ZoomDB", "calllogs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "employeeId", Value: 1}, {Key: "phoneNumber", Value: 1}, {Key: "timestamp", Value: 1}},
+		Options: options.Index().
+			SetName("ingest_unique_call").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"ingestedAt": bson.M{"$exists": true}}),
+	})
+	return err
+}
+
+// dedupeCallLogRows keeps the first row for each key not in existing.
+func dedupeCallLogRows(rows []callLogRow, existing map[string]bool) ([]callLogRow, int) {
+	seen := map[string]bool{}
+	var unique []callLogRow
+	duplicates := 0
+	for _, r := range rows {
+		key := r.key()
+		if seen[key] || existing[key] {
+			duplicates++
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, r)
+	}
+	return unique, duplicates
+}
+
+// existingCallLogKeys loads the keys of stored logs that could collide with the batch.
+func existingCallLogKeys(rows []callLogRow) (map[string]bool, error) {
+	var employees, phones []string
+	minTime, maxTime := rows[0].timestamp, rows[0].timestamp
+	for _, r := range rows {
+		employees = append(employees, r.employeeID)
+		phones = append(phones, r.phoneNumber)
+		if r.timestamp.Before(minTime) {
+			minTime = r.timestamp
+		}
+		if r.timestamp.After(maxTime) {
+			maxTime = r.timestamp
+		}
+	}
+
+	collection := config.GetCollection("ZoomDB", "calllogs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"employeeId":  bson.M{"$in": removeDuplicates(employees)},
+		"phoneNumber": bson.M{"$in": removeDuplicates(phones)},
+		"timestamp":   bson.M{"$gte": minTime, "$lte": maxTime},
+	}
+	projection := options.Find().SetProjection(bson.M{"employeeId": 1, "phoneNumber": 1, "timestamp": 1})
+
+	cursor, err := collection.Find(ctx, filter, projection)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for _, doc := range docs {
+		keys[callLogKey(leadNumberString(doc["employeeId"]), leadNumberString(doc["phoneNumber"]), anyToTime(doc["timestamp"]))] = true
+	}
+	return keys, nil
+}
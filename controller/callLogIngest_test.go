@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := map[string]string{
+		"9876543210":        "9876543210",
+		"+91 98765-43210":   "919876543210",
+		" (022) 2345.6789 ": "02223456789",
+		"":                  "",
+	}
+	for in, want := range tests {
+		if got := normalizePhoneNumber(in); got != want {
+			t.Errorf("normalizePhoneNumber(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeCallLogRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      map[string]interface{}
+		wantErr  string
+		phone    string
+		duration string
+	}{
+		{
+			name:     "valid with separators",
+			raw:      map[string]interface{}{"employeeId": " E1 ", "phoneNumber": "+91 98765 43210", "timestamp": "2025-01-02T10:00:00Z", "duration": "01:05"},
+			phone:    "919876543210",
+			duration: "65",
+		},
+		{
+			name:     "numeric phone, no duration",
+			raw:      map[string]interface{}{"employeeId": "E1", "phoneNumber": float64(9876543210), "timestamp": float64(1735812000)},
+			phone:    "9876543210",
+			duration: "0",
+		},
+		{name: "missing employee", raw: map[string]interface{}{"phoneNumber": "9876543210", "timestamp": "2025-01-02"}, wantErr: "employeeId is required"},
+		{name: "short phone", raw: map[string]interface{}{"employeeId": "E1", "phoneNumber": "12345", "timestamp": "2025-01-02"}, wantErr: "invalid phoneNumber"},
+		{name: "letters in phone", raw: map[string]interface{}{"employeeId": "E1", "phoneNumber": "98765abc10", "timestamp": "2025-01-02"}, wantErr: "invalid phoneNumber"},
+		{name: "bad timestamp", raw: map[string]interface{}{"employeeId": "E1", "phoneNumber": "9876543210", "timestamp": "soon"}, wantErr: "timestamp"},
+		{name: "future timestamp", raw: map[string]interface{}{"employeeId": "E1", "phoneNumber": "9876543210", "timestamp": time.Now().UTC().Add(72 * time.Hour).Format(time.RFC3339)}, wantErr: "future"},
+		{name: "negative duration", raw: map[string]interface{}{"employeeId": "E1", "phoneNumber": "9876543210", "timestamp": "2025-01-02", "duration": float64(-5)}, wantErr: "negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, err := normalizeCallLogRow(1, tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if row.employeeID != "E1" || row.phoneNumber != tt.phone || row.doc["phoneNumber"] != tt.phone || row.doc["duration"] != tt.duration {
+				t.Errorf("row = %+v, doc = %v", row, row.doc)
+			}
+		})
+	}
+}
+
+func TestParseIngestBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		rows        int
+		wantErr     bool
+	}{
+		{"array", "application/json", `[{"a":1},{"a":2}]`, 2, false},
+		{"wrapped", "application/json", `{"records":[{"a":1}]}`, 1, false},
+		{"ndjson", "application/x-ndjson", "{\"a\":1}\n\n{\"a\":2}\n", 2, false},
+		{"bad ndjson", "application/x-ndjson", "{\"a\":1}\nnope\n", 0, true},
+		{"bad json", "application/json", `[{"a":`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseIngestBody(tt.contentType, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(rows) != tt.rows {
+				t.Errorf("len(rows) = %d, want %d", len(rows), tt.rows)
+			}
+		})
+	}
+}
+
+func TestDedupeCallLogRows(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	rows := []callLogRow{
+		{row: 1, employeeID: "E1", phoneNumber: "111", timestamp: ts},
+		{row: 2, employeeID: "E1", phoneNumber: "111", timestamp: ts},
+		{row: 3, employeeID: "E1", phoneNumber: "222", timestamp: ts},
+		{row: 4, employeeID: "E1", phoneNumber: "333", timestamp: ts},
+	}
+	existing := map[string]bool{callLogKey("E1", "333", ts): true}
+
+	unique, duplicates := dedupeCallLogRows(rows, existing)
+	if duplicates != 2 || len(unique) != 2 || unique[0].row != 1 || unique[1].row != 3 {
+		t.Errorf("unique = %+v, duplicates = %d", unique, duplicates)
+	}
+}
+
+func TestJSONString(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{"E1", "E1"},
+		{float64(9876543210), "9876543210"},
+		{float64(1.5), "1.5"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := jsonString(tt.in); got != tt.want {
+			t.Errorf("jsonString(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDuplicateKeyErrors(t *testing.T) {
+	dup := mongo.WriteError{Index: 0, Code: 11000, Message: "E11000 duplicate key error"}
+	other := mongo.WriteError{Index: 1, Code: 121, Message: "Document failed validation"}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantN    int
+		wantOnly bool
+	}{
+		{"no error", nil, 0, false},
+		{"other error", errors.New("connection reset"), 0, false},
+		{"only duplicates", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: dup}, {WriteError: dup}}}, 2, true},
+		{"mixed", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: dup}, {WriteError: other}}}, 0, false},
+		{"write concern", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: dup}}, WriteConcernError: &mongo.WriteConcernError{Code: 64}}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, only := duplicateKeyErrors(tt.err)
+			if n != tt.wantN || only != tt.wantOnly {
+				t.Errorf("duplicateKeyErrors() = %d, %v; want %d, %v", n, only, tt.wantN, tt.wantOnly)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CallRecord is one call normalised from any dialer collection, so every call
+// metric is computed from the same shape regardless of where it was stored.
+type CallRecord struct {
+	Provider    string    `json:"provider"`
+	EmployeeID  string    `json:"employeeId,omitempty"`
+	StaffName   string    `json:"staffName,omitempty"`
+	PhoneNumber string    `json:"phoneNumber"`
+	Timestamp   time.Time `json:"timestamp"`
+	Duration    int       `json:"duration"`
+	Raw         bson.M    `json:"-"`
+}
+
+// callDecoder turns one provider document into a CallRecord.
+type callDecoder func(doc bson.M) CallRecord
+
+// fetchCallRecords loads the documents matching filter, oldest first, and decodes them.
+func fetchCallRecords(collection *mongo.Collection, filter bson.M, timeField string, decode callDecoder) ([]CallRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: timeField, Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	records := make([]CallRecord, 0, len(docs))
+	for _, doc := range docs {
+		records = append(records, decode(doc))
+	}
+	return records, nil
+}
+
+// summarizeCalls builds a ReportBlock from records sorted by time.
+func summarizeCalls(records []CallRecord, weights map[string]float64) ReportBlock {
+	if len(records) == 0 {
+		return ReportBlock{}
+	}
+
+	block := ReportBlock{TotalCount: len(records)}
+	dials := newRepeatDialCounter()
+
+	for _, r := range records {
+		block.TotalDuration += r.Duration
+		if r.Duration > 0 {
+			block.NonZeroDurationCount++
+		} else {
+			block.ZeroDurationCount++
+		}
+		dials.add(r.PhoneNumber, r.Duration)
+	}
+
+	// First and last call (sorted already)
+	block.FirstCallObject = records[0].Raw
+	block.LastCallObject = records[len(records)-1].Raw
+
+	dials.apply(&block)
+	dials.applyWeights(&block, weights)
+
+	return block
+}
+
+// dailyCallTotals sums call duration per day (DD-MM-YYYY, UTC), filling days
+// without calls with zero. With split-attribution weights it also fills
+// WeightedTime, counting numbers missing from weights in full.
+func dailyCallTotals(records []CallRecord, weights map[string]float64, start, end time.Time) []EveryDayReport {
+	totals := make(map[string]int)
+	weighted := make(map[string]float64)
+	for _, r := range records {
+		day := r.Timestamp.UTC().Format("02-01-2006")
+		totals[day] += r.Duration
+
+		weight, ok := weights[r.PhoneNumber]
+		if !ok {
+			weight = 1
+		}
+		weighted[day] += float64(r.Duration) * weight
+	}
+
+	var results []EveryDayReport
+	for d := start; !d.After(end); d = d.Add(24 * time.Hour) {
+		dateStr := d.Format("02-01-2006")
+		day := EveryDayReport{
+			Date:      dateStr,
+			TotalTime: totals[dateStr],
+		}
+		if weights != nil {
+			weightedTime := math.Round(weighted[dateStr]*100) / 100
+			day.WeightedTime = &weightedTime
+		}
+		results = append(results, day)
+	}
+	return results
+}
+
+// anyToSeconds reads a duration stored as a number, a numeric string or a
+// "mm:ss" / "hh:mm:ss" string. Anything unreadable counts as 0.
+func anyToSeconds(val interface{}) int {
+	switch v := val.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float32:
+		return int(math.Round(float64(v)))
+	case float64:
+		return int(math.Round(v))
+	case primitive.Decimal128:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			return 0
+		}
+		return int(math.Round(f))
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0
+		}
+		if strings.Contains(s, ":") {
+			seconds := 0
+			for _, part := range strings.Split(s, ":") {
+				n, err := strconv.Atoi(strings.TrimSpace(part))
+				if err != nil {
+					return 0
+				}
+				seconds = seconds*60 + n
+			}
+			return seconds
+		}
+		if n, err := strconv.Atoi(s); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return int(math.Round(f))
+		}
+	}
+	return 0
+}
+
+var callTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"02-01-2006 15:04:05",
+	"02/01/2006 15:04:05",
+	"2006-01-02",
+}
+
+// anyToTime reads a call time stored as a BSON date, a date string or epoch
+// seconds/milliseconds. Strings without a zone are taken as UTC.
+func anyToTime(val interface{}) time.Time {
+	switch v := val.(type) {
+	case time.Time:
+		return v.UTC()
+	case primitive.DateTime:
+		return v.Time().UTC()
+	case primitive.Timestamp:
+		return time.Unix(int64(v.T), 0).UTC()
+	case int64:
+		return epochToTime(v)
+	case int32:
+		return epochToTime(int64(v))
+	case float64:
+		return epochToTime(int64(v))
+	case string:
+		return parseCallTime(v, time.UTC)
+	}
+	return time.Time{}
+}
+
+// parseCallTime parses a call time string; values without a zone are read in loc.
+func parseCallTime(value string, loc *time.Location) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range callTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func epochToTime(n int64) time.Time {
+	// Anything past year 2286 in seconds is really milliseconds
+	if n > 1e10 {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
+
+// NumberCallSummary totals the calls made to one phone number.
+type NumberCallSummary struct {
+	Number        string    `json:"number"`
+	Attempts      int       `json:"attempts"`
+	Connected     int       `json:"connected"`
+	TotalDuration int       `json:"totalDuration"`
+	FirstCall     time.Time `json:"firstCall"`
+	LastCall      time.Time `json:"lastCall"`
+}
+
+// summarizeByNumber groups records by phone number, most attempted first.
+func summarizeByNumber(records []CallRecord) []NumberCallSummary {
+	byNumber := map[string]*NumberCallSummary{}
+	var order []string
+
+	for _, r := range records {
+		if r.PhoneNumber == "" {
+			continue
+		}
+		entry, ok := byNumber[r.PhoneNumber]
+		if !ok {
+			entry = &NumberCallSummary{Number: r.PhoneNumber, FirstCall: r.Timestamp, LastCall: r.Timestamp}
+			byNumber[r.PhoneNumber] = entry
+			order = append(order, r.PhoneNumber)
+		}
+
+		entry.Attempts++
+		entry.TotalDuration += r.Duration
+		if r.Duration > 0 {
+			entry.Connected++
+		}
+		if r.Timestamp.Before(entry.FirstCall) {
+			entry.FirstCall = r.Timestamp
+		}
+		if r.Timestamp.After(entry.LastCall) {
+			entry.LastCall = r.Timestamp
+		}
+	}
+
+	summaries := make([]NumberCallSummary, 0, len(order))
+	for _, number := range order {
+		summaries = append(summaries, *byNumber[number])
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Attempts > summaries[j].Attempts
+	})
+	return summaries
+}
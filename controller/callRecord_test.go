@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDailyCallTotalsWeights(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 2, 23, 59, 59, 0, time.UTC)
+	records := []CallRecord{
+		{PhoneNumber: "111", Timestamp: start.Add(time.Hour), Duration: 60},
+		{PhoneNumber: "222", Timestamp: start.Add(2 * time.Hour), Duration: 30},
+	}
+
+	unweighted := dailyCallTotals(records, nil, start, end)
+	if len(unweighted) != 2 || unweighted[0].TotalTime != 90 || unweighted[1].TotalTime != 0 {
+		t.Fatalf("unweighted totals = %+v", unweighted)
+	}
+	if unweighted[0].WeightedTime != nil {
+		t.Errorf("WeightedTime should be unset without weights")
+	}
+
+	weighted := dailyCallTotals(records, map[string]float64{"111": 0.5}, start, end)
+	if weighted[0].TotalTime != 90 {
+		t.Errorf("TotalTime = %d, want 90", weighted[0].TotalTime)
+	}
+	if weighted[0].WeightedTime == nil || *weighted[0].WeightedTime != 60 {
+		t.Errorf("WeightedTime = %v, want 60", weighted[0].WeightedTime)
+	}
+	if weighted[1].WeightedTime == nil || *weighted[1].WeightedTime != 0 {
+		t.Errorf("empty day WeightedTime = %v, want 0", weighted[1].WeightedTime)
+	}
+}
+
+func TestAnyToSeconds(t *testing.T) {
+	dec, _ := primitive.ParseDecimal128("42.6")
+	tests := []struct {
+		in   interface{}
+		want int
+	}{
+		{nil, 0},
+		{int(12), 12},
+		{int32(13), 13},
+		{int64(14), 14},
+		{float32(1.5), 2},
+		{float64(59.4), 59},
+		{dec, 43},
+		{"", 0},
+		{" 75 ", 75},
+		{"12.5", 13},
+		{"01:30", 90},
+		{"1:02:03", 3723},
+		{"1:xx", 0},
+		{"abc", 0},
+		{true, 0},
+	}
+
+	for _, tt := range tests {
+		if got := anyToSeconds(tt.in); got != tt.want {
+			t.Errorf("anyToSeconds(%#v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAnyToTime(t *testing.T) {
+	want := time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC)
+	ist := time.FixedZone("IST", 5*3600+1800)
+
+	tests := []struct {
+		name string
+		in   interface{}
+		want time.Time
+	}{
+		{"time", want.In(ist), want},
+		{"bson datetime", primitive.NewDateTimeFromTime(want), want},
+		{"bson timestamp", primitive.Timestamp{T: uint32(want.Unix())}, want},
+		{"epoch seconds", want.Unix(), want},
+		{"epoch seconds int32", int32(want.Unix()), want},
+		{"epoch millis", want.UnixMilli(), want},
+		{"epoch float", float64(want.Unix()), want},
+		{"rfc3339 with zone", "2025-03-04T15:50:30+05:30", want},
+		{"sql layout", "2025-03-04 10:20:30", want},
+		{"dd-mm-yyyy", "04-03-2025 10:20:30", want},
+		{"dd/mm/yyyy", "04/03/2025 10:20:30", want},
+		{"date only", "2025-03-04", time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"garbage", "yesterday", time.Time{}},
+		{"unsupported", true, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := anyToTime(tt.in); !got.Equal(tt.want) {
+				t.Errorf("anyToTime(%#v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCallTimeLocation(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	got := parseCallTime("2025-03-04 10:00:00", ist)
+	want := time.Date(2025, 3, 4, 4, 30, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("parseCallTime in IST = %v, want %v", got, want)
+	}
+}
+
+func TestSummarizeCalls(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	records := []CallRecord{
+		{PhoneNumber: "111", Timestamp: base, Duration: 0, Raw: bson.M{"n": 1}},
+		{PhoneNumber: "111", Timestamp: base.Add(time.Minute), Duration: 40},
+		{PhoneNumber: "222", Timestamp: base.Add(2 * time.Minute), Duration: 20, Raw: bson.M{"n": 3}},
+	}
+
+	block := summarizeCalls(records, nil)
+	if block.TotalCount != 3 || block.TotalDuration != 60 || block.ZeroDurationCount != 1 || block.NonZeroDurationCount != 2 {
+		t.Errorf("summarizeCalls totals = %+v", block)
+	}
+	if block.UniqueNumbers != 2 || block.UniqueConnected != 2 {
+		t.Errorf("unique numbers = %d/%d, want 2/2", block.UniqueNumbers, block.UniqueConnected)
+	}
+	if block.FirstCallObject.(bson.M)["n"] != 1 || block.LastCallObject.(bson.M)["n"] != 3 {
+		t.Errorf("first/last call = %v/%v", block.FirstCallObject, block.LastCallObject)
+	}
+
+	if empty := summarizeCalls(nil, nil); empty.TotalCount != 0 || empty.FirstCallObject != nil {
+		t.Errorf("summarizeCalls(nil) = %+v, want empty block", empty)
+	}
+}
+
+func TestSummarizeByNumber(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	records := []CallRecord{
+		{PhoneNumber: "222", Timestamp: base.Add(time.Hour), Duration: 10},
+		{PhoneNumber: "111", Timestamp: base.Add(2 * time.Hour), Duration: 0},
+		{PhoneNumber: "", Timestamp: base, Duration: 5},
+		{PhoneNumber: "111", Timestamp: base, Duration: 30},
+	}
+
+	got := summarizeByNumber(records)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	first := got[0]
+	if first.Number != "111" || first.Attempts != 2 || first.Connected != 1 || first.TotalDuration != 30 {
+		t.Errorf("first = %+v", first)
+	}
+	if !first.FirstCall.Equal(base) || !first.LastCall.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("first call window = %v..%v", first.FirstCall, first.LastCall)
+	}
+}
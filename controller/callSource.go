@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	providerCallLogs = "calllogs"
+	providerAvyukta  = "avyukta"
+)
+
+// CallSource is one dialer vendor's call collection.
+type CallSource interface {
+	// Name is the provider name used for report sections and staff aliases.
+	Name() string
+	// PhoneField is the stored field holding the dialled number.
+	PhoneField() string
+	// Decode maps one stored document onto a CallRecord.
+	Decode(doc bson.M) CallRecord
+	// Fetch returns the staff member's calls in the range, oldest first.
+	// extra narrows the query further, e.g. to a list of lead numbers.
+	Fetch(staff models.Staff, aliases aliasRegistry, start, end time.Time, extra bson.M) ([]CallRecord, error)
+}
+
+// collectionCallSource is a CallSource driven by a config.CallProviderConfig.
+type collectionCallSource struct {
+	cfg config.CallProviderConfig
+}
+
+func (s collectionCallSource) Name() string {
+	return s.cfg.Name
+}
+
+func (s collectionCallSource) PhoneField() string {
+	return s.cfg.PhoneField
+}
+
+func (s collectionCallSource) Decode(doc bson.M) CallRecord {
+	record := CallRecord{
+		Provider:    s.cfg.Name,
+		PhoneNumber: leadNumberString(doc[s.cfg.PhoneField]),
+		Timestamp:   anyToTime(doc[s.cfg.TimeField]),
+		Duration:    anyToSeconds(doc[s.cfg.DurationField]),
+		Raw:         doc,
+	}
+
+	if s.matchesByName() {
+		record.StaffName, _ = doc[s.cfg.StaffField].(string)
+	} else {
+		record.EmployeeID = leadNumberString(doc[s.cfg.StaffField])
+	}
+
+	return record
+}
+
+func (s collectionCallSource) Fetch(staff models.Staff, aliases aliasRegistry, start, end time.Time, extra bson.M) ([]CallRecord, error) {
+	filter := s.staffFilter(staff, aliases)
+	filter[s.cfg.TimeField] = bson.M{"$gte": start, "$lte": end}
+	for key, value := range extra {
+		filter[key] = value
+	}
+
+	collection := config.GetCollection("ZoomDB", s.cfg.Collection)
+	return fetchCallRecords(collection, filter, s.cfg.TimeField, s.Decode)
+}
+
+func (s collectionCallSource) matchesByName() bool {
+	return s.cfg.Match == config.CallMatchName
+}
+
+// staffFilter applies the provider's staff matching strategy: by employeeId,
+// or by the staff name and its registered aliases for this provider.
+func (s collectionCallSource) staffFilter(staff models.Staff, aliases aliasRegistry) bson.M {
+	if s.matchesByName() {
+		return bson.M{s.cfg.StaffField: bson.M{"$in": nameMatchers(aliases.names(staff, s.cfg.Name))}}
+	}
+	return bson.M{s.cfg.StaffField: staff.EmployeeID}
+}
+
+// callSources is the configured providers in config order.
+type callSources []CallSource
+
+func loadCallSources() callSources {
+	var sources callSources
+	for _, cfg := range config.CallProviders() {
+		sources = append(sources, collectionCallSource{cfg: cfg})
+	}
+	return sources
+}
+
+// get returns the named source. calllogs and avyukta are always configured.
+func (s callSources) get(name string) CallSource {
+	for _, source := range s {
+		if source.Name() == name {
+			return source
+		}
+	}
+	return nil
+}
+
+// extra returns the configured vendors beyond calllogs and avyukta, which
+// have dedicated report sections.
+func (s callSources) extra() callSources {
+	var sources callSources
+	for _, source := range s {
+		if source.Name() != providerCallLogs && source.Name() != providerAvyukta {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// getSourceCallReport builds the ReportBlock of one provider for a staff member.
+func getSourceCallReport(source CallSource, staff models.Staff, aliases aliasRegistry, start, end time.Time) ReportBlock {
+	records, err := source.Fetch(staff, aliases, start, end, nil)
+	if err != nil {
+		fmt.Println("Error fetching logs:", err)
+		return ReportBlock{}
+	}
+
+	return summarizeCalls(records, nil)
+}
+
+// getDailySourceSummary totals one provider's talk time per day for a staff member.
+func getDailySourceSummary(source CallSource, staff models.Staff, aliases aliasRegistry, start, end time.Time) ([]EveryDayReport, error) {
+	records, err := source.Fetch(staff, aliases, start, end, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return dailyCallTotals(records, nil, start, end), nil
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/models"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCollectionCallSourceDecode(t *testing.T) {
+	avyukta := collectionCallSource{cfg: config.CallProviderConfig{
+		Name: "avyukta", Match: config.CallMatchName, StaffField: "full_name",
+		PhoneField: "phone_number", TimeField: "call_date", DurationField: "lenth_in_sec",
+	}}
+	calllogs := collectionCallSource{cfg: config.CallProviderConfig{
+		Name: "calllogs", Match: config.CallMatchEmployeeID, StaffField: "employeeId",
+		PhoneField: "phoneNumber", TimeField: "timestamp", DurationField: "duration",
+	}}
+
+	byName := avyukta.Decode(bson.M{
+		"full_name": "Amit Kumar", "phone_number": int64(9876543210),
+		"call_date": "2025-01-02 10:00:00", "lenth_in_sec": "45",
+	})
+	if byName.StaffName != "Amit Kumar" || byName.EmployeeID != "" || byName.PhoneNumber != "9876543210" || byName.Duration != 45 {
+		t.Errorf("avyukta record = %+v", byName)
+	}
+	if !byName.Timestamp.Equal(time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("avyukta timestamp = %v", byName.Timestamp)
+	}
+
+	byID := calllogs.Decode(bson.M{"employeeId": "E1", "phoneNumber": "111", "duration": int32(12)})
+	if byID.EmployeeID != "E1" || byID.StaffName != "" || byID.Provider != "calllogs" || byID.Duration != 12 {
+		t.Errorf("calllogs record = %+v", byID)
+	}
+
+	staff := models.Staff{EmployeeID: "E1", Name: "Amit Kumar"}
+	if filter := calllogs.staffFilter(staff, nil); filter["employeeId"] != "E1" {
+		t.Errorf("employeeId filter = %v", filter)
+	}
+	nameFilter := avyukta.staffFilter(staff, aliasRegistry{"E1": {"avyukta": {"Amit K"}}})
+	in, ok := nameFilter["full_name"].(bson.M)["$in"].(bson.A)
+	if !ok || len(in) != 2 {
+		t.Errorf("name filter = %v, want two name patterns", nameFilter)
+	}
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go_fiber_Zoom_Report/config"
 	"go_fiber_Zoom_Report/models"
@@ -15,7 +16,6 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -33,60 +33,91 @@ type StaffReport struct {
 	Profile       string                    `json:"profile"`
 	Attendee      int                       `json:"attendee"`
 	TotalAttendee int                       `json:"totalAttendees"`
+	Sessions      []AttendeeSession         `json:"sessions,omitempty"`
 	Sales         map[string]int            `json:"sales"`
+	SalesCredit   SalesCredit               `json:"salesCredit"`
 	YearSale      map[string]map[string]int `json:"yearSale"`
-	DilerReport   ReportBlock               `json:"dilerReport"`
-	CRMReport     ReportBlock               `json:"crmReport"`
-	AdvisorReport ReportBlock               `json:"advisorReport"`
-	AvyuktaReport ReportBlock               `json:"avyuktaReport"`
+	// FiscalYearSale groups the same sales by fiscal year of Date of Enrollment
+	FiscalYearSale map[string]map[string]int `json:"fiscalYearSale"`
+	DilerReport    ReportBlock               `json:"dilerReport"`
+	CRMReport      ReportBlock               `json:"crmReport"`
+	AdvisorReport  ReportBlock               `json:"advisorReport"`
+	AvyuktaReport  ReportBlock               `json:"avyuktaReport"`
+	OtherReport    ReportBlock               `json:"otherReport"`
+	LeadOverlap    LeadOverlap               `json:"leadOverlap"`
+	// ProviderReports holds one section per extra vendor from CALL_PROVIDERS_FILE
+	ProviderReports map[string]ReportBlock `json:"providerReports,omitempty"`
+	// IncompleteLeads names lead sources that failed to load; the lead blocks
+	// and OtherReport miss their numbers
+	IncompleteLeads []string `json:"incompleteLeads,omitempty"`
 }
 
 type StaffDailyReport struct {
-	Name              string                    `json:"name"`
-	Branch            string                    `json:"branch"`
-	EmployeeID        string                    `json:"employeeId"`
-	Profile           string                    `json:"profile"`
-	Attendee          int                       `json:"attendee"`
-	TotalAttendee     int                       `json:"totalAttendees"`
-	Registration      int                       `json:"registration"`
-	TotalRegistration int                       `json:"totalRegistration"`
-	Intrested         int                       `json:"intrested"`
-	TotalIntrested    int                       `json:"totalIntrested"`
-	Sales             map[string]int            `json:"sales"`
-	DilerReport       []EveryDayReport          `json:"dilerReport"`
-	YearSale          map[string]map[string]int `json:"yearSale"`
-	CRMReport         []EveryDayReport          `json:"crmReport"`
-	AdvisorReport     []EveryDayReport          `json:"advisorReport"`
-	AvyuktaReport     []EveryDayReport          `json:"avyuktaReport"`
+	Name              string                      `json:"name"`
+	Branch            string                      `json:"branch"`
+	EmployeeID        string                      `json:"employeeId"`
+	Profile           string                      `json:"profile"`
+	Attendee          int                         `json:"attendee"`
+	TotalAttendee     int                         `json:"totalAttendees"`
+	Registration      int                         `json:"registration"`
+	TotalRegistration int                         `json:"totalRegistration"`
+	Intrested         int                         `json:"intrested"`
+	TotalIntrested    int                         `json:"totalIntrested"`
+	Sessions          []AttendeeSession           `json:"sessions,omitempty"`
+	Sales             map[string]int              `json:"sales"`
+	SalesCredit       SalesCredit                 `json:"salesCredit"`
+	DilerReport       []EveryDayReport            `json:"dilerReport"`
+	YearSale          map[string]map[string]int   `json:"yearSale"`
+	FiscalYearSale    map[string]map[string]int   `json:"fiscalYearSale"`
+	CRMReport         []EveryDayReport            `json:"crmReport"`
+	AdvisorReport     []EveryDayReport            `json:"advisorReport"`
+	AvyuktaReport     []EveryDayReport            `json:"avyuktaReport"`
+	ProviderReports   map[string][]EveryDayReport `json:"providerReports,omitempty"`
+	IncompleteLeads   []string                    `json:"incompleteLeads,omitempty"`
 }
 
 type EveryDayReport struct {
 	Date      string `bson:"_id" json:"date"`
 	TotalTime int    `bson:"totalTime" json:"totalTime"`
+	// WeightedTime is set in split attribution mode so shared numbers are not double-counted
+	WeightedTime *float64 `bson:"weightedTime,omitempty" json:"weightedTime,omitempty"`
 }
 
 type ReportBlock struct {
-	TotalCount           int         `json:"totalCount"`
-	NonZeroDurationCount int         `json:"nonZeroDurationCount"`
-	ZeroDurationCount    int         `json:"zeroDurationCount"`
-	TotalDuration        int         `json:"totalDuration"`
-	FirstCallObject      interface{} `json:"firstCallObject"`
-	LastCallObject       interface{} `json:"lastCallObject"`
+	TotalCount           int          `json:"totalCount"`
+	NonZeroDurationCount int          `json:"nonZeroDurationCount"`
+	ZeroDurationCount    int          `json:"zeroDurationCount"`
+	TotalDuration        int          `json:"totalDuration"`
+	UniqueNumbers        int          `json:"uniqueNumbers"`
+	UniqueConnected      int          `json:"uniqueConnected"`
+	AvgAttemptsPerNumber float64      `json:"avgAttemptsPerNumber"`
+	TopRepeatedNumbers   []RepeatDial `json:"topRepeatedNumbers"`
+	WeightedCount        float64      `json:"weightedCount,omitempty"`
+	WeightedDuration     float64      `json:"weightedDuration,omitempty"`
+	FirstCallObject      interface{}  `json:"firstCallObject"`
+	LastCallObject       interface{}  `json:"lastCallObject"`
 }
 
-type SalesLead struct {
-	L1               string    `bson:"L1"`
-	L2L3             string    `bson:"L2/L3"`
-	DateOfEnrollment time.Time `bson:"Date of Enrollment"`
-	StudentName      string    `bson:"Student Name"`
-	Source           string    `bson:"Source"`
-	Year             string    `bson:"Year"`
+// RepeatDial is one phone number and how often it was dialled in the range.
+type RepeatDial struct {
+	Number        string `json:"number"`
+	Attempts      int    `json:"attempts"`
+	Connected     int    `json:"connected"`
+	TotalDuration int    `json:"totalDuration"`
 }
 
-type YearSales struct {
-	ID   string `bson:"_id"`
-	L1   int    `bson:"L1"`
-	L2L3 int    `bson:"L2L3"`
+// SalesLead is one salesleads row. The money fields are named by
+// SALES_AMOUNT_FIELD and SALES_FEE_FIELD, so they are read from Fields. ID is
+// whatever _id the row has: imported sheets do not always use ObjectIDs.
+type SalesLead struct {
+	ID               interface{} `bson:"_id"`
+	L1               string      `bson:"L1"`
+	L2L3             string      `bson:"L2/L3"`
+	DateOfEnrollment time.Time   `bson:"Date of Enrollment"`
+	StudentName      string      `bson:"Student Name"`
+	Source           string      `bson:"Source"`
+	Year             string      `bson:"Year"`
+	Fields           bson.M      `bson:",inline"`
 }
 
 type AdvisingController struct{}
@@ -104,12 +135,56 @@ func GetCombineReport(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
 	}
 
+	totalWindow, err := queryTotalWindow(c, endOfDay)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid totalWindow. Use all, fytd, year or rolling with months"})
+	}
+
+	attributionMode := strings.ToLower(c.Query("attribution", config.LeadAttributionMode()))
+	if !validAttributionMode(attributionMode) {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid attribution. Use shared, exclusive or split"})
+	}
+
 	fmt.Println("🕐 Start:", startOfDay)
 	fmt.Println("🕐 End:", endOfDay)
 
+	withSessions := c.QueryBool("sessions")
+	dedupe := c.QueryBool("dedupe", config.DedupeCalls())
+	sources := loadCallSources()
+	var window time.Duration
+	if dedupe {
+		window, err = queryDuplicateWindow(c)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid windowSec"})
+		}
+		sources = sources.withDedupe(window)
+	}
+
+	// A closed month is served from its frozen snapshot, which only holds the
+	// figures for the options it was closed with
+	period, closed, err := closedPeriodFor(startOfDay, endOfDay)
+	if err != nil {
+		fmt.Println("Error fetching closed period:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check closed period"})
+	}
+	if closed {
+		frozen, err := period.reportOptions()
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Invalid TOTAL_WINDOW"})
+		}
+		requested := newReportOptions(attributionMode, totalWindow, withSessions, dedupe, window)
+		if !requested.equal(frozen) {
+			return c.Status(409).JSON(fiber.Map{
+				"error":   "This month is closed; its report is frozen with other options",
+				"month":   period.Month,
+				"options": frozen,
+			})
+		}
+		c.Set("X-Period-Closed", period.Month)
+		return c.JSON(period.Report)
+	}
+
 	staffCollection := config.GetCollection("ZoomDB", "staffs")
-	callLogsCollection := config.GetCollection("ZoomDB", "calllogs")
-	avyuktaCallCallection := config.GetCollection("ZoomDB", "avyuktacalls")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -151,6 +226,34 @@ func GetCombineReport(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode staff"})
 	}
 
+	finalReport := buildCombineReport(staffList, combineReportOptions{
+		Start:           startOfDay,
+		End:             endOfDay,
+		Total:           totalWindow,
+		AttributionMode: attributionMode,
+		WithSessions:    withSessions,
+		Sources:         sources,
+	})
+
+	return c.JSON(finalReport)
+}
+
+// combineReportOptions are the parsed settings one StaffReport run is built with.
+type combineReportOptions struct {
+	Start           time.Time
+	End             time.Time
+	Total           utils.TotalWindow
+	AttributionMode string
+	WithSessions    bool
+	Sources         callSources
+}
+
+// buildCombineReport computes the StaffReport of every staff member, sorted by
+// branch and name.
+func buildCombineReport(staffList []models.Staff, opts combineReportOptions) []StaffReport {
+	callLogs := opts.Sources.get(providerCallLogs)
+	aliases := loadAliasRegistry()
+
 	var finalReport []StaffReport
 
 	// Example Loop
@@ -161,68 +264,50 @@ func GetCombineReport(c *fiber.Ctx) error {
 		branch := s.Branch
 		profile := s.Profile
 
-		var (
-			advisingNumbers []string
-			crmNumbers      []string
-			zoomNumbers     []string
-			dialerNumbers   []string
-		)
-
-		wg := sync.WaitGroup{}
-		wg.Add(4)
-
-		go func() {
-			defer wg.Done()
-			advisingNumbers, _ = (&AdvisingController{}).GetAdvisingNumbersByEmployeeID(empID)
-		}()
-		go func() {
-			defer wg.Done()
-			crmNumbers, _ = (&crmLeadsController{}).GetCRMLeadsNumbersByEmployeeID(empID)
-		}()
-		go func() {
-			defer wg.Done()
-			zoomNumbers, _ = (&ClientLeadsController{}).GetClientLeadsNumbersByEmployeeID(empID)
-		}()
-
-		go func() {
-			defer wg.Done()
-			dialerNumbers, _ = (&DialerLeadsController{}).GetDialerLeadsNumbersByEmployeeID(empID)
-		}()
-
-		// go func(){
-		// 	defer wg.Done()
-		// 	attendee,_ =(&Attendees{}).getAllAttendeeCount(name, startOfDay, endOfDay)
-		// }()
-
-		wg.Wait()
-
-		// Combine both slices
-		allNumbers := append(zoomNumbers, dialerNumbers...)
-		// Optional: remove duplicates
-		allNumbers = removeDuplicates(allNumbers)
+		leads, err := fetchLeadNumbers(empID)
+		if err != nil {
+			fmt.Println("Error fetching lead numbers for", empID, ":", err)
+		}
+		attribution := attributeLeads(leads, opts.AttributionMode)
 
 		// ✅ Calculate each report section
-		dilerReport := getCallReport(callLogsCollection, empID, allNumbers, startOfDay, endOfDay)
-		crmReport := getCallReport(callLogsCollection, empID, crmNumbers, startOfDay, endOfDay)
-		advisorReport := getCallReport(callLogsCollection, empID, advisingNumbers, startOfDay, endOfDay)
-		avyuktaReport := getAvyuktaCallReport(avyuktaCallCallection, name, startOfDay, endOfDay)
-		attendee, totalAttendees := getAttendeeCounts(name, startOfDay, endOfDay)
-		sales := getSalesReport(name, startOfDay, endOfDay)
-		yearSale := getSalesReportByYear(name)
+		dilerReport := getCallReport(callLogs, s, attribution.Blocks[blockDiler], attribution.Weights, opts.Start, opts.End)
+		crmReport := getCallReport(callLogs, s, attribution.Blocks[blockCRM], attribution.Weights, opts.Start, opts.End)
+		advisorReport := getCallReport(callLogs, s, attribution.Blocks[blockAdvisor], attribution.Weights, opts.Start, opts.End)
+		otherReport := getOtherCallReport(callLogs, s, leads.all(), opts.Start, opts.End)
+		avyuktaReport := getSourceCallReport(opts.Sources.get(providerAvyukta), s, aliases, opts.Start, opts.End)
+
+		var providerReports map[string]ReportBlock
+		for _, source := range opts.Sources.extra() {
+			if providerReports == nil {
+				providerReports = map[string]ReportBlock{}
+			}
+			providerReports[source.Name()] = getSourceCallReport(source, s, aliases, opts.Start, opts.End)
+		}
+		attendees := getAttendeeMetrics(aliases.names(s, aliasSourceAttendees), opts.Start, opts.End, opts.Total, opts.WithSessions)
+		sales, salesCredit := getSalesReport(aliases.names(s, aliasSourceSales), opts.Start, opts.End)
+		yearSale, fiscalYearSale := getSalesReportByYear(aliases.names(s, aliasSourceSales), opts.Total)
 
 		finalReport = append(finalReport, StaffReport{
-			Name:          name,
-			Branch:        branch,
-			EmployeeID:    empID,
-			Profile:       profile,
-			Attendee:      attendee,
-			TotalAttendee: totalAttendees,
-			Sales:         sales,
-			YearSale:      yearSale,
-			DilerReport:   dilerReport,
-			CRMReport:     crmReport,
-			AdvisorReport: advisorReport,
-			AvyuktaReport: avyuktaReport,
+			Name:            name,
+			Branch:          branch,
+			EmployeeID:      empID,
+			Profile:         profile,
+			Attendee:        attendees.Attendees,
+			TotalAttendee:   attendees.TotalAttendees,
+			Sessions:        attendees.Sessions,
+			Sales:           sales,
+			SalesCredit:     salesCredit,
+			YearSale:        yearSale,
+			FiscalYearSale:  fiscalYearSale,
+			DilerReport:     dilerReport,
+			CRMReport:       crmReport,
+			AdvisorReport:   advisorReport,
+			AvyuktaReport:   avyuktaReport,
+			OtherReport:     otherReport,
+			LeadOverlap:     attribution.Overlap,
+			ProviderReports: providerReports,
+			IncompleteLeads: incompleteLeadSources(err),
 		})
 	}
 
@@ -239,7 +324,7 @@ func GetCombineReport(c *fiber.Ctx) error {
 		return false
 	})
 
-	return c.JSON(finalReport)
+	return finalReport
 }
 
 func DayByReportEveryStaff(c *fiber.Ctx) error {
@@ -251,12 +336,31 @@ func DayByReportEveryStaff(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
 	}
 
+	totalWindow, err := queryTotalWindow(c, endOfDay)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid totalWindow. Use all, fytd, year or rolling with months"})
+	}
+
+	attributionMode := strings.ToLower(c.Query("attribution", config.LeadAttributionMode()))
+	if !validAttributionMode(attributionMode) {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid attribution. Use shared, exclusive or split"})
+	}
+
 	fmt.Println("🕐 Start:", startOfDay)
 	fmt.Println("🕐 End:", endOfDay)
 
+	aliases := loadAliasRegistry()
 	staffCollection := config.GetCollection("ZoomDB", "staffs")
-	callLogsCollection := config.GetCollection("ZoomDB", "calllogs")
-	avyuktaCallLogs := config.GetCollection("ZoomDB", "avyuktacalls")
+	withSessions := c.QueryBool("sessions")
+	sources := loadCallSources()
+	if c.QueryBool("dedupe", config.DedupeCalls()) {
+		window, err := queryDuplicateWindow(c)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid windowSec"})
+		}
+		sources = sources.withDedupe(window)
+	}
+	callLogs := sources.get(providerCallLogs)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -308,70 +412,53 @@ func DayByReportEveryStaff(c *fiber.Ctx) error {
 		branch := s.Branch
 		profile := s.Profile
 
-		var (
-			advisingNumbers []string
-			crmNumbers      []string
-			zoomNumbers     []string
-			dialerNumbers   []string
-		)
-
-		wg := sync.WaitGroup{}
-		wg.Add(4)
-
-		go func() {
-			defer wg.Done()
-			advisingNumbers, _ = (&AdvisingController{}).GetAdvisingNumbersByEmployeeID(empID)
-		}()
-		go func() {
-			defer wg.Done()
-			crmNumbers, _ = (&crmLeadsController{}).GetCRMLeadsNumbersByEmployeeID(empID)
-		}()
-		go func() {
-			defer wg.Done()
-			zoomNumbers, _ = (&ClientLeadsController{}).GetClientLeadsNumbersByEmployeeID(empID)
-		}()
-
-		go func() {
-			defer wg.Done()
-			dialerNumbers, _ = (&DialerLeadsController{}).GetDialerLeadsNumbersByEmployeeID(empID)
-		}()
-
-		wg.Wait()
-
-		// Combine both slices
-		allNumbers := append(zoomNumbers, dialerNumbers...)
-		// Optional: remove duplicates
-		allNumbers = removeDuplicates(allNumbers)
+		leads, err := fetchLeadNumbers(empID)
+		if err != nil {
+			fmt.Println("Error fetching lead numbers for", empID, ":", err)
+		}
+		attribution := attributeLeads(leads, attributionMode)
 
 		// ✅ Calculate each report section
-		dilerReport, _ := getDailyCallReport(callLogsCollection, empID, allNumbers, startOfDay, endOfDay)
-		crmReport, _ := getDailyCallReport(callLogsCollection, empID, crmNumbers, startOfDay, endOfDay)
-		advisorReport, _ := getDailyCallReport(callLogsCollection, empID, advisingNumbers, startOfDay, endOfDay)
-		avyuktaReport, _ := getDailyAvyuktaCallSummary(avyuktaCallLogs, name, startOfDay, endOfDay)
+		dilerReport, _ := getDailyCallReport(callLogs, s, attribution.Blocks[blockDiler], attribution.Weights, startOfDay, endOfDay)
+		crmReport, _ := getDailyCallReport(callLogs, s, attribution.Blocks[blockCRM], attribution.Weights, startOfDay, endOfDay)
+		advisorReport, _ := getDailyCallReport(callLogs, s, attribution.Blocks[blockAdvisor], attribution.Weights, startOfDay, endOfDay)
+		teams := aliases.names(s, aliasSourceAttendees)
+		avyuktaReport, _ := getDailySourceSummary(sources.get(providerAvyukta), s, aliases, startOfDay, endOfDay)
+
+		var providerReports map[string][]EveryDayReport
+		for _, source := range sources.extra() {
+			if providerReports == nil {
+				providerReports = map[string][]EveryDayReport{}
+			}
+			providerReports[source.Name()], _ = getDailySourceSummary(source, s, aliases, startOfDay, endOfDay)
+		}
 		// attendee := getAllAttendeeCount(name, startOfDay, endOfDay)
-		attendee, totalAttendees := getAttendeeCounts(name, startOfDay, endOfDay)
-		intrested, totalIntrested := getIntrestedCounts(name, startOfDay, endOfDay)
-		registration, totalRegistration := getRegistrationCounts(name, startOfDay, endOfDay)
-		sales := getSalesReport(name, startOfDay, endOfDay)
-		yearSale := getSalesReportByYear(name)
+		attendees := getAttendeeMetrics(teams, startOfDay, endOfDay, totalWindow, withSessions)
+		sales, salesCredit := getSalesReport(aliases.names(s, aliasSourceSales), startOfDay, endOfDay)
+		yearSale, fiscalYearSale := getSalesReportByYear(aliases.names(s, aliasSourceSales), totalWindow)
 
 		finalReport = append(finalReport, StaffDailyReport{
 			Name:              name,
 			Branch:            branch,
 			EmployeeID:        empID,
 			Profile:           profile,
-			Attendee:          attendee,
-			TotalAttendee:     totalAttendees,
-			Registration:      registration,
-			TotalRegistration: totalRegistration,
-			Intrested:         intrested,
-			TotalIntrested:    totalIntrested,
+			Attendee:          attendees.Attendees,
+			TotalAttendee:     attendees.TotalAttendees,
+			Registration:      attendees.Registration,
+			TotalRegistration: attendees.TotalRegistration,
+			Intrested:         attendees.Intrested,
+			TotalIntrested:    attendees.TotalIntrested,
+			Sessions:          attendees.Sessions,
 			Sales:             sales,
+			SalesCredit:       salesCredit,
 			YearSale:          yearSale,
+			FiscalYearSale:    fiscalYearSale,
 			DilerReport:       dilerReport,
 			CRMReport:         crmReport,
 			AdvisorReport:     advisorReport,
 			AvyuktaReport:     avyuktaReport,
+			ProviderReports:   providerReports,
+			IncompleteLeads:   incompleteLeadSources(err),
 		})
 	}
 
@@ -391,296 +478,33 @@ func DayByReportEveryStaff(c *fiber.Ctx) error {
 	return c.JSON(finalReport)
 }
 
-func getAvyuktaCallReport(callLogsCollection *mongo.Collection, EmployeeName string, start, end time.Time) ReportBlock {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	filter := bson.M{
-		"full_name": EmployeeName,
-		"call_date": bson.M{
-			"$gte": start,
-			"$lte": end,
-		},
-	}
-
-	// Create aggregation pipeline
-	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: filter}},
-		{{Key: "$sort", Value: bson.M{"call_date": 1}}},
-	}
-
-	cursor, err := callLogsCollection.Aggregate(ctx, pipeline)
-	if err != nil {
-		fmt.Println("Error fetching logs:", err)
-		return ReportBlock{}
-	}
-
-	defer cursor.Close(ctx)
-
-	var logs []bson.M
-	cursor.All(ctx, &logs)
-
-	if len(logs) == 0 {
-		return ReportBlock{}
-	}
-
-	totalCount := len(logs)
-	totalDuration := 0
-	nonZero := 0
-	zero := 0
-
-	// Loop through logs to calculate durations
-	for _, log := range logs {
-		durationAny, ok := log["lenth_in_sec"]
-		if !ok {
-			continue
-		}
+func getCallReport(callLogs CallSource, staff models.Staff, numbers []string, weights map[string]float64, start, end time.Time) ReportBlock {
+	extra := bson.M{callLogs.PhoneField(): bson.M{"$in": numbers}}
 
-		var duration int
-		switch v := durationAny.(type) {
-		case int32:
-			duration = int(v)
-		case int64:
-			duration = int(v)
-		case float64:
-			duration = int(v)
-		}
-
-		totalDuration += duration
-		if duration > 0 {
-			nonZero++
-		} else {
-			zero++
-		}
-	}
-	// First and last call (sorted already)
-	firstCall := logs[0]
-	lastCall := logs[len(logs)-1]
-
-	return ReportBlock{
-		TotalCount:           totalCount,
-		NonZeroDurationCount: nonZero,
-		ZeroDurationCount:    zero,
-		TotalDuration:        totalDuration,
-		FirstCallObject:      firstCall,
-		LastCallObject:       lastCall,
-	}
+	return buildCallReport(callLogs, staff, extra, weights, start, end)
 }
 
-func getCallReport(callLogsCollection *mongo.Collection, employeeID string, numbers []string, start, end time.Time) ReportBlock {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	filter := bson.M{
-		"employeeId": employeeID,
-		"timestamp": bson.M{
-			"$gte": start,
-			"$lte": end,
-		},
-		"phoneNumber": bson.M{"$in": numbers},
-	}
-
-	pipeline := mongo.Pipeline{
-		{{"$match", filter}},
-		{{"$sort", bson.M{"timestamp": 1}}},
-	}
-
-	cursor, err := callLogsCollection.Aggregate(ctx, pipeline)
+// buildCallReport summarises the staff member's calls matching extra. When
+// weights is set, calls are also totalled with their per-number weight.
+func buildCallReport(callLogs CallSource, staff models.Staff, extra bson.M, weights map[string]float64, start, end time.Time) ReportBlock {
+	records, err := callLogs.Fetch(staff, nil, start, end, extra)
 	if err != nil {
 		fmt.Println("Error fetching logs:", err)
 		return ReportBlock{}
 	}
-	var logs []bson.M
-	cursor.All(ctx, &logs)
-
-	if len(logs) == 0 {
-		return ReportBlock{}
-	}
-
-	totalCount := len(logs)
-	totalDuration := 0
-	nonZero := 0
-	zero := 0
-
-	for _, log := range logs {
-		durStr, _ := log["duration"].(string)
-		dur, _ := strconv.Atoi(durStr)
-		totalDuration += dur
-		if dur > 0 {
-			nonZero++
-		} else {
-			zero++
-		}
-	}
 
-	firstCall := logs[0]
-	lastCall := logs[len(logs)-1]
-
-	return ReportBlock{
-		TotalCount:           totalCount,
-		NonZeroDurationCount: nonZero,
-		ZeroDurationCount:    zero,
-		TotalDuration:        totalDuration,
-		FirstCallObject:      firstCall,
-		LastCallObject:       lastCall,
-	}
+	return summarizeCalls(records, weights)
 }
 
-func getDailyCallReport(callLogsCollection *mongo.Collection, employeeID string, numbers []string, start, end time.Time) ([]EveryDayReport, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func getDailyCallReport(callLogs CallSource, staff models.Staff, numbers []string, weights map[string]float64, start, end time.Time) ([]EveryDayReport, error) {
+	extra := bson.M{callLogs.PhoneField(): bson.M{"$in": numbers}}
 
-	// Match filter
-	matchStage := bson.D{{Key: "$match", Value: bson.M{
-		"employeeId":  employeeID,
-		"timestamp":   bson.M{"$gte": start, "$lte": end},
-		"phoneNumber": bson.M{"$in": numbers},
-	}}}
-
-	// Convert timestamp to date string (DD-MM format)
-	addFieldsStage := bson.D{{Key: "$addFields", Value: bson.M{
-		"dateStr": bson.M{
-			"$dateToString": bson.M{
-				"format": "%d-%m-%Y",
-				"date":   "$timestamp",
-			},
-		},
-	}}}
-
-	// Group by that formatted date
-	groupStage := bson.D{{
-		Key: "$group",
-		Value: bson.M{
-			"_id": "$dateStr",
-			"totalTime": bson.M{
-				"$sum": bson.M{
-					"$toInt": "$duration",
-				},
-			},
-		},
-	}}
-
-	// Sort by date
-	sortStage := bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}}
-
-	pipeline := mongo.Pipeline{matchStage, addFieldsStage, groupStage, sortStage}
-
-	cursor, err := callLogsCollection.Aggregate(ctx, pipeline)
+	records, err := callLogs.Fetch(staff, nil, start, end, extra)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
-
-	// var results []EveryDayReport
-	// if err := cursor.All(ctx, &results); err != nil {
-	// 	return nil, err
-	// }
 
-	// // Rename _id → Date (if needed)
-	// for i := range results {
-	// 	results[i].Date = results[i].Date // already mapped via bson tag
-	// }
-
-	// return results, nil
-
-	var aggResults []EveryDayReport
-	if err := cursor.All(ctx, &aggResults); err != nil {
-		return nil, err
-	}
-
-	// Convert aggregation results into a map (for quick lookup)
-	resultMap := make(map[string]int)
-	for _, r := range aggResults {
-		resultMap[r.Date] = r.TotalTime
-	}
-
-	// Fill missing days with totalTime = 0
-	var finalResults []EveryDayReport
-	for d := start; !d.After(end); d = d.Add(24 * time.Hour) {
-		dateStr := d.Format("02-01-2006") // same format as $dateToString
-		totalTime := resultMap[dateStr]
-		finalResults = append(finalResults, EveryDayReport{
-			Date:      dateStr,
-			TotalTime: totalTime,
-		})
-	}
-
-	return finalResults, nil
-}
-
-func getDailyAvyuktaCallSummary(collection *mongo.Collection, fullName string, start, end time.Time) ([]EveryDayReport, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Stage 1: Filter between startDate and endDate
-	matchStage := bson.D{{Key: "$match", Value: bson.M{
-		"full_name": fullName,
-		"call_date": bson.M{"$gte": start, "$lte": end},
-	}}}
-
-	// Step 2: Convert call_date to a formatted string (like "30-10")
-	addFieldsStage := bson.D{{Key: "$addFields", Value: bson.M{
-		"dateStr": bson.M{
-			"$dateToString": bson.M{
-				"format": "%d-%m-%Y",
-				"date":   "$call_date",
-			},
-		},
-	}}}
-
-	// Step 3: Group by date (DD-MM) and sum total time
-	groupStage := bson.D{{Key: "$group", Value: bson.M{
-		"_id":       "$dateStr",
-		"totalTime": bson.M{"$sum": "$lenth_in_sec"},
-	}}}
-
-	// Step 4: Sort by date ascending
-	sortStage := bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}}
-
-	pipeline := mongo.Pipeline{matchStage, addFieldsStage, groupStage, sortStage}
-
-	cursor, err := collection.Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	// var results []EveryDayReport
-	// if err := cursor.All(ctx, &results); err != nil {
-	// 	return nil, err
-	// }
-
-	// Rename _id to date for clean output
-	// for i := range results {
-	// 	results[i]["date"] = results[i]["_id"]
-	// 	delete(results[i], "_id")
-	// }
-
-	// return results, nil
-
-	var aggResults []EveryDayReport
-	if err := cursor.All(ctx, &aggResults); err != nil {
-		return nil, err
-	}
-
-	// Convert aggregation results into a map for quick lookup
-	resultMap := make(map[string]int)
-	for _, r := range aggResults {
-		resultMap[r.Date] = r.TotalTime
-	}
-
-	// Generate all dates between start and end
-	var fullResults []EveryDayReport
-	for d := start; !d.After(end); d = d.Add(24 * time.Hour) {
-		dateStr := d.Format("02-01-2006") // same format used in $dateToString
-		totalTime := resultMap[dateStr]
-		fullResults = append(fullResults, EveryDayReport{
-			Date:      dateStr,
-			TotalTime: totalTime,
-		})
-	}
-
-	return fullResults, nil
+	return dailyCallTotals(records, weights, start, end), nil
 }
 
 // func getAllAttendeeCount(name string, start, end time.Time) int {
@@ -724,338 +548,80 @@ func getDailyAvyuktaCallSummary(collection *mongo.Collection, fullName string, s
 // 	return total
 // }
 
-func getAttendeeCounts(team string, start, end time.Time) (int, int) {
-	collection := config.GetCollection("ZoomDB", "attendees")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-
-	// --- Pipeline 1: Sum between start and end ---
-	pipelineRange := mongo.Pipeline{
-		{{"$match", bson.D{
-			{"Team", team},
-			{"Date", bson.D{
-				{"$gte", start},
-				{"$lte", end},
-			}},
-		}}},
-		{{"$group", bson.D{
-			{"_id", nil},
-			{"total", bson.D{{"$sum", "$Attendees"}}},
-		}}},
-	}
-
-	var rangeResult []bson.M
-	rangeCur, _ := collection.Aggregate(ctx, pipelineRange)
-	rangeCur.All(ctx, &rangeResult)
-
-	var dateRangeAttendees int
-	// if len(rangeResult) > 0 {
-	// 	dateRangeAttendees = rangeResult[0]["total"].(int)
-	// }
-
-	if len(rangeResult) > 0 {
-		switch v := rangeResult[0]["total"].(type) {
-		case int32:
-			dateRangeAttendees = int(v)
-		case int64:
-			dateRangeAttendees = int(v)
-		case float64:
-			dateRangeAttendees = int(v)
-		}
-	}
-
-	// --- Pipeline 2: Sum all attendees for that team (no date filter) ---
-	pipelineTotal := mongo.Pipeline{
-		{{"$match", bson.D{
-			{"Team", team},
-		}}},
-		{{"$group", bson.D{
-			{"_id", nil},
-			{"total", bson.D{{"$sum", "$Attendees"}}},
-		}}},
-	}
-
-	var totalResult []bson.M
-	totalCur, _ := collection.Aggregate(ctx, pipelineTotal)
-	totalCur.All(ctx, &totalResult)
-
-	var totalAttendees int
-	// if len(totalResult) > 0 {
-	// 	totalAttendees = totalResult[0]["total"].(int)
-	// }
-
-	if len(totalResult) > 0 {
-		switch v := totalResult[0]["total"].(type) {
-		case int32:
-			totalAttendees = int(v)
-		case int64:
-			totalAttendees = int(v)
-		case float64:
-			totalAttendees = int(v)
-		}
-	}
-
-	return dateRangeAttendees, totalAttendees
-}
-
-func getIntrestedCounts(team string, start, end time.Time) (int, int) {
-	collection := config.GetCollection("ZoomDB", "attendees")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-
-	// --- Pipeline 1: Sum between start and end ---
-	pipelineRange := mongo.Pipeline{
-		{{"$match", bson.D{
-			{"Team", team},
-			{"Date", bson.D{
-				{"$gte", start},
-				{"$lte", end},
-			}},
-		}}},
-		{{"$group", bson.D{
-			{"_id", nil},
-			{"total", bson.D{{"$sum", "$Intrested"}}},
-		}}},
-	}
-
-	var rangeResult []bson.M
-	rangeCur, _ := collection.Aggregate(ctx, pipelineRange)
-	rangeCur.All(ctx, &rangeResult)
-
-	var dateRangeIntrested int
-	// if len(rangeResult) > 0 {
-	// 	dateRangeAttendees = rangeResult[0]["total"].(int)
-	// }
-
-	if len(rangeResult) > 0 {
-		switch v := rangeResult[0]["total"].(type) {
-		case int32:
-			dateRangeIntrested = int(v)
-		case int64:
-			dateRangeIntrested = int(v)
-		case float64:
-			dateRangeIntrested = int(v)
-		}
-	}
-
-	// --- Pipeline 2: Sum all attendees for that team (no date filter) ---
-	pipelineTotal := mongo.Pipeline{
-		{{"$match", bson.D{
-			{"Team", team},
-		}}},
-		{{"$group", bson.D{
-			{"_id", nil},
-			{"total", bson.D{{"$sum", "$Intrested"}}},
-		}}},
-	}
-
-	var totalResult []bson.M
-	totalCur, _ := collection.Aggregate(ctx, pipelineTotal)
-	totalCur.All(ctx, &totalResult)
-
-	var totalIntrested int
-	// if len(totalResult) > 0 {
-	// 	totalAttendees = totalResult[0]["total"].(int)
-	// }
-
-	if len(totalResult) > 0 {
-		switch v := totalResult[0]["total"].(type) {
-		case int32:
-			totalIntrested = int(v)
-		case int64:
-			totalIntrested = int(v)
-		case float64:
-			totalIntrested = int(v)
-		}
-	}
-
-	return dateRangeIntrested, totalIntrested
-}
-
-func getRegistrationCounts(team string, start, end time.Time) (int, int) {
-	collection := config.GetCollection("ZoomDB", "attendees")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
+// getSalesReport counts the sales credited to names in the range, and the
+// fractional credit they earn under the configured split-credit rule.
+func getSalesReport(names []string, start, end time.Time) (map[string]int, SalesCredit) {
+	identity := newSalesIdentity(names)
+	rule := config.SalesCredit()
+	results := fetchSalesLeads(names, bson.M{
+		"Date of Enrollment": bson.M{"$gte": start, "$lte": end},
+	})
 
-	// --- Pipeline 1: Sum between start and end ---
-	pipelineRange := mongo.Pipeline{
-		{{"$match", bson.D{
-			{"Team", team},
-			{"Date", bson.D{
-				{"$gte", start},
-				{"$lte", end},
-			}},
-		}}},
-		{{"$group", bson.D{
-			{"_id", nil},
-			{"total", bson.D{{"$sum", "$Registration"}}},
-		}}},
+	count := map[string]int{
+		"L1":   0,
+		"L2L3": 0,
 	}
 
-	var rangeResult []bson.M
-	rangeCur, _ := collection.Aggregate(ctx, pipelineRange)
-	rangeCur.All(ctx, &rangeResult)
+	var credit SalesCredit
 
-	var dateRangeRegistration int
-	// if len(rangeResult) > 0 {
-	// 	dateRangeAttendees = rangeResult[0]["total"].(int)
-	// }
-
-	if len(rangeResult) > 0 {
-		switch v := rangeResult[0]["total"].(type) {
-		case int32:
-			dateRangeRegistration = int(v)
-		case int64:
-			dateRangeRegistration = int(v)
-		case float64:
-			dateRangeRegistration = int(v)
+	for _, r := range results {
+		credit.add(identity, r, rule)
+		if identity.credits(r.L1) {
+			count["L1"]++
 		}
-	}
-
-	// --- Pipeline 2: Sum all attendees for that team (no date filter) ---
-	pipelineTotal := mongo.Pipeline{
-		{{"$match", bson.D{
-			{"Team", team},
-		}}},
-		{{"$group", bson.D{
-			{"_id", nil},
-			{"total", bson.D{{"$sum", "$Registration"}}},
-		}}},
-	}
-
-	var totalResult []bson.M
-	totalCur, _ := collection.Aggregate(ctx, pipelineTotal)
-	totalCur.All(ctx, &totalResult)
-
-	var totalRegistration int
-	// if len(totalResult) > 0 {
-	// 	totalAttendees = totalResult[0]["total"].(int)
-	// }
-
-	if len(totalResult) > 0 {
-		switch v := totalResult[0]["total"].(type) {
-		case int32:
-			totalRegistration = int(v)
-		case int64:
-			totalRegistration = int(v)
-		case float64:
-			totalRegistration = int(v)
+		if identity.credits(r.L2L3) {
+			count["L2L3"]++
 		}
 	}
 
-	return dateRangeRegistration, totalRegistration
+	return count, credit.rounded()
 }
 
-func getSalesReport(name string, start, end time.Time) map[string]int {
-
-	collection := config.GetCollection("ZoomDB", "salesleads")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	// Date filter
-	filter := bson.M{
-		"Date of Enrollment": bson.M{
-			"$gte": start,
-			"$lte": end,
-		},
-		// Only documents where L1 or L2/L3 CONTAINS the name
-		"$or": []bson.M{
-			{"L1": bson.M{"$regex": name, "$options": "i"}},
-			{"L2/L3": bson.M{"$regex": name, "$options": "i"}},
-		},
-	}
-
-	cursor, err := collection.Find(ctx, filter)
-	if err != nil {
-		fmt.Println("Error fetching:", err)
-		return nil
-	}
-	defer cursor.Close(ctx)
-
-	var results []SalesLead
-	if err := cursor.All(ctx, &results); err != nil {
-		fmt.Println("Decode error:", err)
-		return nil
-	}
+// getSalesReportByYear counts credited sales over the total window, grouped by
+// the Year field and by fiscal year of Date of Enrollment (e.g. "FY2024-25").
+func getSalesReportByYear(names []string, total utils.TotalWindow) (map[string]map[string]int, map[string]map[string]int) {
+	identity := newSalesIdentity(names)
+	fyStart := config.FiscalYearStartMonth()
 
-	count := map[string]int{
-		"L1":   0,
-		"L2L3": 0,
+	extra := bson.M{}
+	if total.Bounded() {
+		extra["Date of Enrollment"] = bson.M{"$gte": total.Start, "$lte": total.End}
 	}
 
-	for _, r := range results {
+	byYear := map[string]map[string]int{}
+	byFiscalYear := map[string]map[string]int{}
 
-		// If L1 contains given name
-		if strings.Contains(strings.ToLower(r.L1), strings.ToLower(name)) {
-			count["L1"]++
+	for _, r := range fetchSalesLeads(names, extra) {
+		l1, l2l3 := identity.credits(r.L1), identity.credits(r.L2L3)
+		if !l1 && !l2l3 {
+			continue
 		}
 
-		// If L2/L3 contains given name
-		if strings.Contains(strings.ToLower(r.L2L3), strings.ToLower(name)) {
-			count["L2L3"]++
+		countYearSale(byYear, r.Year, l1, l2l3)
+		if !r.DateOfEnrollment.IsZero() {
+			countYearSale(byFiscalYear, utils.FiscalYearLabel(r.DateOfEnrollment, fyStart), l1, l2l3)
 		}
 	}
 
-	return count
+	return byYear, byFiscalYear
 }
 
-func getSalesReportByYear(name string) map[string]map[string]int {
-
-	collection := config.GetCollection("ZoomDB", "salesleads")
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-
-	pipeline := mongo.Pipeline{
-		{{"$match", bson.D{
-			{"$or", bson.A{
-				bson.D{{"L1", bson.D{{"$regex", name}, {"$options", "i"}}}},
-				bson.D{{"L2/L3", bson.D{{"$regex", name}, {"$options", "i"}}}},
-			}},
-		}}},
-		{{"$group", bson.D{
-			{"_id", "$Year"},
-			{"L1", bson.D{{"$sum", bson.D{
-				{"$cond", bson.A{
-					bson.D{{"$regexMatch", bson.D{{"input", "$L1"}, {"regex", name}, {"options", "i"}}}},
-					1, 0,
-				}},
-			}}}},
-			{"L2L3", bson.D{{"$sum", bson.D{
-				{"$cond", bson.A{
-					bson.D{{"$regexMatch", bson.D{{"input", "$L2/L3"}, {"regex", name}, {"options", "i"}}}},
-					1, 0,
-				}},
-			}}}},
-		}}},
+// countYearSale adds one sale's L1 and L2/L3 credit under key.
+func countYearSale(years map[string]map[string]int, key string, l1, l2l3 bool) {
+	year, ok := years[key]
+	if !ok {
+		year = map[string]int{"L1": 0, "L2L3": 0}
+		years[key] = year
 	}
-
-	cursor, err := collection.Aggregate(ctx, pipeline)
-	if err != nil {
-		fmt.Println("Aggregation error:", err)
-		return nil
+	if l1 {
+		year["L1"]++
 	}
-
-	var results []YearSales
-	if err := cursor.All(ctx, &results); err != nil {
-		fmt.Println("Cursor decode error:", err)
-		return nil
+	if l2l3 {
+		year["L2L3"]++
 	}
-
-	final := map[string]map[string]int{}
-
-	for _, r := range results {
-		final[r.ID] = map[string]int{
-			"L1":   r.L1,
-			"L2L3": r.L2L3,
-		}
-	}
-
-	return final
 }
 
+// cleanName strips the L1/L2/3/OV role suffix from a credited name.
 func cleanName(value string) string {
 	re := regexp.MustCompile(`\s+(L\d(\/\d)?|OV).*`)
 	return strings.TrimSpace(re.ReplaceAllString(value, ""))
@@ -1231,6 +797,113 @@ func (c *DialerLeadsController) GetDialerLeadsNumbersByEmployeeID(employeeID str
 	return mobileNumbers, nil
 }
 
+// staffLeadNumbers holds every lead number assigned to one employee, per source.
+type staffLeadNumbers struct {
+	Advising []string
+	CRM      []string
+	Client   []string
+	Dialer   []string
+}
+
+// leadSourceError lists the lead sources that could not be loaded, so the
+// known-number set for the employee is incomplete.
+type leadSourceError struct {
+	failed map[string]error
+}
+
+func (e *leadSourceError) Error() string {
+	var parts []string
+	for _, source := range e.sources() {
+		parts = append(parts, source+": "+e.failed[source].Error())
+	}
+	return "lead sources failed: " + strings.Join(parts, "; ")
+}
+
+// sources returns the failed source names, sorted.
+func (e *leadSourceError) sources() []string {
+	sources := make([]string, 0, len(e.failed))
+	for source := range e.failed {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// incompleteLeadSources names the sources behind a fetchLeadNumbers error, for
+// flagging a staff row whose lead blocks and otherReport are incomplete.
+func incompleteLeadSources(err error) []string {
+	var sourceErr *leadSourceError
+	if errors.As(err, &sourceErr) {
+		return sourceErr.sources()
+	}
+	if err != nil {
+		return []string{"all"}
+	}
+	return nil
+}
+
+// fetchLeadNumbers loads the four lead sources for an employee in parallel.
+// The numbers of the sources that loaded are returned even when others fail.
+func fetchLeadNumbers(empID string) (staffLeadNumbers, error) {
+	var leads staffLeadNumbers
+	var advisingErr, crmErr, clientErr, dialerErr error
+
+	wg := sync.WaitGroup{}
+	wg.Add(4)
+
+	go func() {
+		defer wg.Done()
+		leads.Advising, advisingErr = (&AdvisingController{}).GetAdvisingNumbersByEmployeeID(empID)
+	}()
+	go func() {
+		defer wg.Done()
+		leads.CRM, crmErr = (&crmLeadsController{}).GetCRMLeadsNumbersByEmployeeID(empID)
+	}()
+	go func() {
+		defer wg.Done()
+		leads.Client, clientErr = (&ClientLeadsController{}).GetClientLeadsNumbersByEmployeeID(empID)
+	}()
+	go func() {
+		defer wg.Done()
+		leads.Dialer, dialerErr = (&DialerLeadsController{}).GetDialerLeadsNumbersByEmployeeID(empID)
+	}()
+
+	wg.Wait()
+
+	failed := map[string]error{}
+	for source, err := range map[string]error{
+		leadSourceAdvising: advisingErr,
+		leadSourceCRM:      crmErr,
+		leadSourceClient:   clientErr,
+		leadSourceDialer:   dialerErr,
+	} {
+		if err != nil {
+			failed[source] = err
+		}
+	}
+	if len(failed) > 0 {
+		return leads, &leadSourceError{failed: failed}
+	}
+	return leads, nil
+}
+
+// dialerBlock combines the zoom/client and dialer numbers used by the diler report.
+func (l staffLeadNumbers) dialerBlock() []string {
+	var numbers []string
+	numbers = append(numbers, l.Client...)
+	numbers = append(numbers, l.Dialer...)
+	return removeDuplicates(numbers)
+}
+
+// all returns every assigned number across the four sources.
+func (l staffLeadNumbers) all() []string {
+	var numbers []string
+	numbers = append(numbers, l.dialerBlock()...)
+	numbers = append(numbers, l.CRM...)
+	numbers = append(numbers, l.Advising...)
+	return removeDuplicates(numbers)
+}
+
 func removeDuplicates(arr []string) []string {
 	keys := make(map[string]bool)
 	list := []string{}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCountYearSale(t *testing.T) {
+	years := map[string]map[string]int{}
+	countYearSale(years, "FY2024-25", true, false)
+	countYearSale(years, "FY2024-25", true, true)
+	countYearSale(years, "FY2025-26", false, true)
+
+	want := map[string]map[string]int{
+		"FY2024-25": {"L1": 2, "L2L3": 1},
+		"FY2025-26": {"L1": 0, "L2L3": 1},
+	}
+	if !reflect.DeepEqual(years, want) {
+		t.Errorf("countYearSale() = %v, want %v", years, want)
+	}
+}
@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/models"
+	"go_fiber_Zoom_Report/utils"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CommissionLine is the part of a statement paid at one slab.
+type CommissionLine struct {
+	From    float64 `json:"from"`
+	To      float64 `json:"to,omitempty"`
+	Units   float64 `json:"units"`
+	PerSale float64 `json:"perSale"`
+	Amount  float64 `json:"amount"`
+}
+
+// CommissionStatement is one staff member's commission for one month, paid on
+// the weighted sales credit from the split-credit rule.
+type CommissionStatement struct {
+	Month      string           `json:"month"`
+	Name       string           `json:"name"`
+	Branch     string           `json:"branch"`
+	EmployeeID string           `json:"employeeId"`
+	Profile    string           `json:"profile"`
+	Sales      map[string]int   `json:"sales"`
+	Credit     SalesCredit      `json:"credit"`
+	HasRule    bool             `json:"hasRule"`
+	Lines      []CommissionLine `json:"lines"`
+	Commission float64          `json:"commission"`
+}
+
+// GetCommissionStatements returns the monthly commission statement for every
+// staff member matching the optional employeeId/branch/profile filters.
+func GetCommissionStatements(c *fiber.Ctx) error {
+	month, statements, status, err := commissionStatementsFromQuery(c)
+	if err != nil {
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	total := 0.0
+	for _, s := range statements {
+		total += s.Commission
+	}
+
+	return c.JSON(fiber.Map{
+		"month":      month,
+		"statements": statements,
+		"total":      utils.Round2(total),
+	})
+}
+
+// ExportCommissionPayout returns the month's statements as a payout CSV.
+func ExportCommissionPayout(c *fiber.Ctx) error {
+	month, statements, status, err := commissionStatementsFromQuery(c)
+	if err != nil {
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"Month", "Employee ID", "Name", "Branch", "Profile", "L1 Sales", "L2/L3 Sales", "Credit", "Commission"})
+	for _, s := range statements {
+		w.Write([]string{
+			s.Month,
+			s.EmployeeID,
+			s.Name,
+			s.Branch,
+			s.Profile,
+			strconv.Itoa(s.Sales["L1"]),
+			strconv.Itoa(s.Sales["L2L3"]),
+			strconv.FormatFloat(s.Credit.Total, 'f', 2, 64),
+			strconv.FormatFloat(s.Commission, 'f', 2, 64),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to write CSV"})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="commission-%s.csv"`, month))
+	return c.Send(buf.Bytes())
+}
+
+// commissionStatementsFromQuery parses ?month=YYYY-MM (default this month) and
+// the staff filters. On failure it returns the HTTP status to respond with.
+func commissionStatementsFromQuery(c *fiber.Ctx) (string, []CommissionStatement, int, error) {
+	month := c.Query("month", time.Now().UTC().Format("2006-01"))
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return "", nil, 400, errors.New("Invalid month. Use YYYY-MM")
+	}
+	end := start.AddDate(0, 1, 0).Add(-time.Millisecond)
+
+	staffList, err := fetchReportStaff(staffFilterFromQuery(c.Query("employeeId"), c.Query("branch"), c.Query("profile")))
+	if err != nil {
+		return "", nil, 500, errors.New("Failed to fetch staff")
+	}
+
+	rules, err := config.CommissionRules()
+	if err != nil {
+		fmt.Println("Error loading commission rules:", err)
+		return "", nil, 500, errors.New("Failed to load commission rules")
+	}
+
+	return month, buildCommissionStatements(staffList, rules, month, start, end), 200, nil
+}
+
+// buildCommissionStatements credits each staff member's sales for the month and
+// prices the credit with their profile's slab rule.
+func buildCommissionStatements(staffList []models.Staff, rules map[string]config.CommissionRule, month string, start, end time.Time) []CommissionStatement {
+	aliases := loadAliasRegistry()
+
+	statements := []CommissionStatement{}
+	for _, s := range staffList {
+		sales, credit := getSalesReport(aliases.names(s, aliasSourceSales), start, end)
+
+		statement := CommissionStatement{
+			Month:      month,
+			Name:       s.Name,
+			Branch:     s.Branch,
+			EmployeeID: s.EmployeeID,
+			Profile:    s.Profile,
+			Sales:      sales,
+			Credit:     credit,
+			Lines:      []CommissionLine{},
+		}
+
+		if rule, ok := config.CommissionRuleFor(rules, s.Profile); ok {
+			statement.HasRule = true
+			statement.Lines = applyCommissionSlabs(rule, credit.Total)
+			for _, line := range statement.Lines {
+				statement.Commission += line.Amount
+			}
+			statement.Commission = utils.Round2(statement.Commission)
+		}
+
+		statements = append(statements, statement)
+	}
+
+	return statements
+}
+
+// applyCommissionSlabs prices credit against the rule's slabs, either slab by
+// slab or, for retroactive rules, all at the highest slab reached.
+func applyCommissionSlabs(rule config.CommissionRule, credit float64) []CommissionLine {
+	lines := []CommissionLine{}
+	if credit <= 0 || len(rule.Slabs) == 0 {
+		return lines
+	}
+
+	if rule.Retroactive {
+		reached := -1
+		for i, slab := range rule.Slabs {
+			if credit >= slab.From {
+				reached = i
+			}
+		}
+		if reached < 0 {
+			return lines
+		}
+		slab := rule.Slabs[reached]
+		return append(lines, CommissionLine{
+			From:    slab.From,
+			Units:   utils.Round2(credit),
+			PerSale: slab.PerSale,
+			Amount:  utils.Round2(credit * slab.PerSale),
+		})
+	}
+
+	for i, slab := range rule.Slabs {
+		if credit <= slab.From {
+			break
+		}
+		upper := credit
+		var to float64
+		if i+1 < len(rule.Slabs) {
+			to = rule.Slabs[i+1].From
+			if to < upper {
+				upper = to
+			}
+		}
+		units := upper - slab.From
+		lines = append(lines, CommissionLine{
+			From:    slab.From,
+			To:      to,
+			Units:   utils.Round2(units),
+			PerSale: slab.PerSale,
+			Amount:  utils.Round2(units * slab.PerSale),
+		})
+	}
+
+	return lines
+}
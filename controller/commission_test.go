@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/config"
+	"reflect"
+	"testing"
+)
+
+func TestApplyCommissionSlabs(t *testing.T) {
+	slabs := []config.CommissionSlab{{From: 0, PerSale: 500}, {From: 10, PerSale: 750}, {From: 20, PerSale: 1000}}
+	tiered := config.CommissionRule{Slabs: slabs}
+	retro := config.CommissionRule{Slabs: slabs, Retroactive: true}
+
+	tests := []struct {
+		name   string
+		rule   config.CommissionRule
+		credit float64
+		want   []CommissionLine
+	}{
+		{"no credit", tiered, 0, []CommissionLine{}},
+		{"no slabs", config.CommissionRule{}, 5, []CommissionLine{}},
+		{"first slab", tiered, 4.5, []CommissionLine{
+			{From: 0, To: 10, Units: 4.5, PerSale: 500, Amount: 2250},
+		}},
+		{"exactly at a boundary", tiered, 10, []CommissionLine{
+			{From: 0, To: 10, Units: 10, PerSale: 500, Amount: 5000},
+		}},
+		{"into the top slab", tiered, 22, []CommissionLine{
+			{From: 0, To: 10, Units: 10, PerSale: 500, Amount: 5000},
+			{From: 10, To: 20, Units: 10, PerSale: 750, Amount: 7500},
+			{From: 20, Units: 2, PerSale: 1000, Amount: 2000},
+		}},
+		{"below a starting threshold", config.CommissionRule{Slabs: []config.CommissionSlab{{From: 5, PerSale: 100}}}, 3, []CommissionLine{}},
+		{"retroactive", retro, 12.5, []CommissionLine{
+			{From: 10, Units: 12.5, PerSale: 750, Amount: 9375},
+		}},
+		{"retroactive below every slab", config.CommissionRule{Slabs: []config.CommissionSlab{{From: 5, PerSale: 100}}, Retroactive: true}, 3, []CommissionLine{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyCommissionSlabs(tt.rule, tt.credit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyCommissionSlabs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
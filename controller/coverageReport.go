@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"fmt"
+	"go_fiber_Zoom_Report/models"
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type StaffCoverageReport struct {
+	Name       string                    `json:"name"`
+	Branch     string                    `json:"branch"`
+	EmployeeID string                    `json:"employeeId"`
+	Profile    string                    `json:"profile"`
+	Sources    map[string]SourceCoverage `json:"sources"`
+	// IncompleteLeads names lead sources that failed to load
+	IncompleteLeads []string `json:"incompleteLeads,omitempty"`
+}
+
+// SourceCoverage compares the leads assigned from one source with the ones called in the range.
+type SourceCoverage struct {
+	Assigned       int            `json:"assigned"`
+	Called         int            `json:"called"`
+	Connected      int            `json:"connected"`
+	Untouched      int            `json:"untouched"`
+	CoveragePct    float64        `json:"coveragePct"`
+	UntouchedLeads UntouchedLeads `json:"untouchedLeads"`
+}
+
+type UntouchedLeads struct {
+	PageInfo
+	Numbers []string `json:"numbers"`
+}
+
+// GetLeadCoverageReport shows, per staff and lead source, how many assigned leads
+// were called and connected in the range, with a page of never-called leads.
+func GetLeadCoverageReport(c *fiber.Ctx) error {
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	page, limit := queryPage(c)
+
+	sourceFilter := c.Query("source")
+	if sourceFilter != "" {
+		if _, ok := leadSourceBlock[sourceFilter]; !ok {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid source. Use advising, crm, client or dialer"})
+		}
+	}
+
+	staffList, err := fetchReportStaff(staffFilterFromQuery(c.Query("employeeId"), c.Query("branch"), c.Query("profile")))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	callLogs := loadCallSources().get(providerCallLogs)
+
+	finalReport := []StaffCoverageReport{}
+	for _, s := range staffList {
+		leads, err := fetchLeadNumbers(s.EmployeeID)
+		if err != nil {
+			fmt.Println("Error fetching lead numbers for", s.EmployeeID, ":", err)
+		}
+		called := getNumberCallStats(callLogs, s, leads.all(), startOfDay, endOfDay)
+
+		sources := map[string]SourceCoverage{}
+		for source, numbers := range leads.bySource() {
+			if sourceFilter != "" && source != sourceFilter {
+				continue
+			}
+			sources[source] = buildSourceCoverage(numbers, called, page, limit)
+		}
+
+		finalReport = append(finalReport, StaffCoverageReport{
+			Name:            s.Name,
+			Branch:          s.Branch,
+			EmployeeID:      s.EmployeeID,
+			Profile:         s.Profile,
+			Sources:         sources,
+			IncompleteLeads: incompleteLeadSources(err),
+		})
+	}
+
+	sort.SliceStable(finalReport, func(i, j int) bool {
+		if finalReport[i].Branch == finalReport[j].Branch {
+			return finalReport[i].Name < finalReport[j].Name
+		}
+		return finalReport[i].Branch < finalReport[j].Branch
+	})
+
+	return c.JSON(finalReport)
+}
+
+// getNumberCallStats returns the calls per number the employee made to numbers in the range.
+func getNumberCallStats(callLogs CallSource, staff models.Staff, numbers []string, start, end time.Time) map[string]NumberCallSummary {
+	extra := bson.M{callLogs.PhoneField(): bson.M{"$in": numbers}}
+
+	stats := map[string]NumberCallSummary{}
+
+	records, err := callLogs.Fetch(staff, nil, start, end, extra)
+	if err != nil {
+		fmt.Println("Error fetching call stats:", err)
+		return stats
+	}
+
+	for _, summary := range summarizeByNumber(records) {
+		stats[summary.Number] = summary
+	}
+	return stats
+}
+
+func buildSourceCoverage(numbers []string, called map[string]NumberCallSummary, page, limit int) SourceCoverage {
+	coverage := SourceCoverage{Assigned: len(numbers)}
+
+	untouched := []string{}
+	for _, number := range numbers {
+		stat, ok := called[number]
+		if !ok {
+			untouched = append(untouched, number)
+			continue
+		}
+		coverage.Called++
+		if stat.Connected > 0 {
+			coverage.Connected++
+		}
+	}
+	coverage.Untouched = len(untouched)
+
+	coverage.CoveragePct = utils.Percent(float64(coverage.Called), float64(coverage.Assigned))
+
+	from, to := pageBounds(len(untouched), page, limit)
+	coverage.UntouchedLeads = UntouchedLeads{
+		PageInfo: PageInfo{Page: page, Limit: limit, Total: len(untouched)},
+		Numbers:  untouched[from:to],
+	}
+
+	return coverage
+}
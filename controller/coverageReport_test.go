@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildSourceCoverage(t *testing.T) {
+	numbers := []string{"111", "222", "333", "444", "555"}
+	called := map[string]NumberCallSummary{
+		"111": {Number: "111", Attempts: 2, Connected: 1},
+		"333": {Number: "333", Attempts: 1, Connected: 0},
+		"999": {Number: "999", Attempts: 1, Connected: 1},
+	}
+
+	tests := []struct {
+		name        string
+		numbers     []string
+		page, limit int
+		want        SourceCoverage
+	}{
+		{
+			name:    "first page",
+			numbers: numbers,
+			page:    1, limit: 2,
+			want: SourceCoverage{
+				Assigned: 5, Called: 2, Connected: 1, Untouched: 3, CoveragePct: 40,
+				UntouchedLeads: UntouchedLeads{PageInfo: PageInfo{Page: 1, Limit: 2, Total: 3}, Numbers: []string{"222", "444"}},
+			},
+		},
+		{
+			name:    "last partial page",
+			numbers: numbers,
+			page:    2, limit: 2,
+			want: SourceCoverage{
+				Assigned: 5, Called: 2, Connected: 1, Untouched: 3, CoveragePct: 40,
+				UntouchedLeads: UntouchedLeads{PageInfo: PageInfo{Page: 2, Limit: 2, Total: 3}, Numbers: []string{"555"}},
+			},
+		},
+		{
+			name:    "no leads assigned",
+			numbers: nil,
+			page:    1, limit: 50,
+			want: SourceCoverage{
+				UntouchedLeads: UntouchedLeads{PageInfo: PageInfo{Page: 1, Limit: 50}, Numbers: []string{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSourceCoverage(tt.numbers, called, tt.page, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildSourceCoverage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/models"
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DuplicateCall is one stored call inside a duplicate group.
+type DuplicateCall struct {
+	ID        interface{} `json:"id"`
+	Timestamp time.Time   `json:"timestamp"`
+	Duration  int         `json:"duration"`
+}
+
+// DuplicateGroup is a set of calls to the same number by the same staff member
+// within the duplicate window. Kept survives a merge; Duplicates are removed.
+type DuplicateGroup struct {
+	EmployeeID    string          `json:"employeeId"`
+	PhoneNumber   string          `json:"phoneNumber"`
+	Kept          DuplicateCall   `json:"kept"`
+	Duplicates    []DuplicateCall `json:"duplicates"`
+	ExtraDuration int             `json:"extraDuration"`
+}
+
+// DuplicateReport is the result of a duplicate scan, and of a merge when Merged is set.
+// ConfirmToken identifies the exact rows found; a merge must echo it back.
+type DuplicateReport struct {
+	WindowSec       int              `json:"windowSec"`
+	Groups          int              `json:"groups"`
+	DuplicateRows   int              `json:"duplicateRows"`
+	ExtraDuration   int              `json:"extraDuration"`
+	ConfirmToken    string           `json:"confirmToken"`
+	Merged          bool             `json:"merged"`
+	Archived        int              `json:"archived"`
+	Deleted         int              `json:"deleted"`
+	DuplicateGroups []DuplicateGroup `json:"duplicateGroups"`
+}
+
+// duplicateArchiveCollection keeps every calllogs row removed by a merge.
+const duplicateArchiveCollection = "calllogs_duplicates"
+
+// duplicateClusters groups time-sorted records into clusters of calls by the
+// same staff to the same number, each within window of the cluster's first call.
+func duplicateClusters(records []CallRecord, window time.Duration) [][]int {
+	var clusters [][]int
+	open := map[string]int{} // staff|phone -> index into clusters
+
+	for i, r := range records {
+		if r.PhoneNumber == "" {
+			clusters = append(clusters, []int{i})
+			continue
+		}
+
+		key := r.EmployeeID + "|" + r.StaffName + "|" + r.PhoneNumber
+		if c, ok := open[key]; ok {
+			first := records[clusters[c][0]]
+			if r.Timestamp.Sub(first.Timestamp) <= window {
+				clusters[c] = append(clusters[c], i)
+				continue
+			}
+		}
+
+		open[key] = len(clusters)
+		clusters = append(clusters, []int{i})
+	}
+
+	return clusters
+}
+
+// keptIndex picks the call a cluster keeps: the longest, earliest on ties.
+func keptIndex(records []CallRecord, cluster []int) int {
+	kept := cluster[0]
+	for _, i := range cluster[1:] {
+		if records[i].Duration > records[kept].Duration {
+			kept = i
+		}
+	}
+	return kept
+}
+
+// dedupeCallRecords drops duplicate calls, keeping one call per cluster in time order.
+func dedupeCallRecords(records []CallRecord, window time.Duration) []CallRecord {
+	keep := make([]bool, len(records))
+	for _, cluster := range duplicateClusters(records, window) {
+		keep[keptIndex(records, cluster)] = true
+	}
+
+	deduped := make([]CallRecord, 0, len(records))
+	for i, r := range records {
+		if keep[i] {
+			deduped = append(deduped, r)
+		}
+	}
+	return deduped
+}
+
+// dedupingCallSource wraps a CallSource so reports ignore duplicates on the fly.
+type dedupingCallSource struct {
+	CallSource
+	window time.Duration
+}
+
+func (s dedupingCallSource) Fetch(staff models.Staff, aliases aliasRegistry, start, end time.Time, extra bson.M) ([]CallRecord, error) {
+	records, err := s.CallSource.Fetch(staff, aliases, start, end, extra)
+	if err != nil {
+		return nil, err
+	}
+	return dedupeCallRecords(records, s.window), nil
+}
+
+// withDedupe wraps every source so duplicate calls are ignored.
+func (s callSources) withDedupe(window time.Duration) callSources {
+	wrapped := make(callSources, 0, len(s))
+	for _, source := range s {
+		wrapped = append(wrapped, dedupingCallSource{CallSource: source, window: window})
+	}
+	return wrapped
+}
+
+// queryDuplicateWindow reads ?windowSec=, falling back to CALL_DUPLICATE_WINDOW_SEC.
+func queryDuplicateWindow(c *fiber.Ctx) (time.Duration, error) {
+	value := c.Query("windowSec")
+	if value == "" {
+		return config.CallDuplicateWindow(), nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid windowSec")
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// GetDuplicateCallLogs is a dry run listing duplicate calllogs rows per employee.
+func GetDuplicateCallLogs(c *fiber.Ctx) error {
+	return duplicateCallLogs(c, false)
+}
+
+// MergeDuplicateCallLogs removes the duplicate calllogs rows found by
+// GetDuplicateCallLogs. It is admin only and needs ?confirm=<confirmToken>
+// from a dry run with the same filters; the removed rows are archived to
+// calllogs_duplicates first.
+func MergeDuplicateCallLogs(c *fiber.Ctx) error {
+	if c.Query("confirm") == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Run GET /calllogs/duplicates first and pass its confirmToken as ?confirm="})
+	}
+	return duplicateCallLogs(c, true)
+}
+
+func duplicateCallLogs(c *fiber.Ctx, merge bool) error {
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	window, err := queryDuplicateWindow(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid windowSec"})
+	}
+
+	provider, _ := config.FindCallProvider(providerCallLogs)
+	source := collectionCallSource{cfg: provider}
+	collection := config.GetCollection("ZoomDB", provider.Collection)
+
+	filter := bson.M{provider.TimeField: bson.M{"$gte": startOfDay, "$lte": endOfDay}}
+	if empID := c.Query("employeeId"); empID != "" {
+		filter[provider.StaffField] = empID
+	}
+
+	records, err := fetchCallRecords(collection, filter, provider.TimeField, source.Decode)
+	if err != nil {
+		fmt.Println("Error fetching logs:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch call logs"})
+	}
+
+	report := DuplicateReport{WindowSec: int(window / time.Second), DuplicateGroups: []DuplicateGroup{}}
+	var duplicates []CallRecord
+	keptIDs := map[interface{}]interface{}{}
+
+	for _, cluster := range duplicateClusters(records, window) {
+		if len(cluster) < 2 {
+			continue
+		}
+
+		kept := keptIndex(records, cluster)
+		group := DuplicateGroup{
+			EmployeeID:  records[kept].EmployeeID,
+			PhoneNumber: records[kept].PhoneNumber,
+			Kept:        duplicateCall(records[kept]),
+		}
+		for _, i := range cluster {
+			if i == kept {
+				continue
+			}
+			group.Duplicates = append(group.Duplicates, duplicateCall(records[i]))
+			group.ExtraDuration += records[i].Duration
+			duplicates = append(duplicates, records[i])
+			keptIDs[records[i].Raw["_id"]] = records[kept].Raw["_id"]
+		}
+
+		report.Groups++
+		report.DuplicateRows += len(group.Duplicates)
+		report.ExtraDuration += group.ExtraDuration
+		report.DuplicateGroups = append(report.DuplicateGroups, group)
+	}
+
+	sort.SliceStable(report.DuplicateGroups, func(i, j int) bool {
+		return report.DuplicateGroups[i].EmployeeID < report.DuplicateGroups[j].EmployeeID
+	})
+
+	var duplicateIDs []interface{}
+	for _, r := range duplicates {
+		duplicateIDs = append(duplicateIDs, r.Raw["_id"])
+	}
+	report.ConfirmToken = duplicateConfirmToken(duplicateIDs, window)
+
+	if !merge || len(duplicateIDs) == 0 {
+		return c.JSON(report)
+	}
+
+	if c.Query("confirm") != report.ConfirmToken {
+		return c.Status(409).JSON(fiber.Map{"error": "Duplicates changed since the dry run; review them again", "report": report})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	archived, err := archiveDuplicateCallLogs(ctx, duplicates, keptIDs, adminActor(c))
+	if err != nil {
+		fmt.Println("Error archiving duplicates:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to archive duplicate call logs"})
+	}
+	report.Archived = archived
+
+	deleted, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": duplicateIDs}})
+	if err != nil {
+		fmt.Println("Error deleting duplicates:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete duplicate call logs", "report": report})
+	}
+	report.Merged = true
+	report.Deleted = int(deleted.DeletedCount)
+
+	return c.JSON(report)
+}
+
+// archiveDuplicateCallLogs copies rows about to be merged away into
+// calllogs_duplicates, recording the row each was merged into. Upserts on _id
+// make a retried merge safe.
+func archiveDuplicateCallLogs(ctx context.Context, duplicates []CallRecord, keptIDs map[interface{}]interface{}, actor string) (int, error) {
+	archivedAt := time.Now().UTC()
+
+	var writes []mongo.WriteModel
+	for _, r := range duplicates {
+		doc := bson.M{}
+		for key, value := range r.Raw {
+			doc[key] = value
+		}
+		doc["mergedInto"] = keptIDs[r.Raw["_id"]]
+		doc["archivedAt"] = archivedAt
+		doc["archivedBy"] = actor
+
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": r.Raw["_id"]}).
+			SetReplacement(doc).
+			SetUpsert(true))
+	}
+
+	archive := config.GetCollection("ZoomDB", duplicateArchiveCollection)
+	result, err := archive.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, err
+	}
+	return int(result.UpsertedCount + result.MatchedCount), nil
+}
+
+// duplicateConfirmToken fingerprints the rows a merge would delete.
+func duplicateConfirmToken(ids []interface{}, window time.Duration) string {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, fmt.Sprint(id))
+	}
+	sort.Strings(keys)
+
+	sum := sha256.Sum256([]byte(strconv.Itoa(int(window/time.Second)) + "|" + strings.Join(keys, ",")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func duplicateCall(r CallRecord) DuplicateCall {
+	return DuplicateCall{ID: r.Raw["_id"], Timestamp: r.Timestamp, Duration: r.Duration}
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func dupRecord(staff, phone string, at time.Time, duration int) CallRecord {
+	return CallRecord{EmployeeID: staff, PhoneNumber: phone, Timestamp: at, Duration: duration}
+}
+
+func TestDuplicateClusters(t *testing.T) {
+	base := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	records := []CallRecord{
+		dupRecord("E1", "9876543210", base, 30),
+		dupRecord("E1", "9876543210", base.Add(20*time.Second), 40),
+		dupRecord("E2", "9876543210", base.Add(25*time.Second), 10),
+		dupRecord("E1", "9876543210", base.Add(90*time.Second), 50),
+		dupRecord("E1", "", base.Add(91*time.Second), 5),
+		dupRecord("E1", "", base.Add(92*time.Second), 5),
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   [][]int
+	}{
+		{"no window", 0, [][]int{{0}, {1}, {2}, {3}, {4}, {5}}},
+		{"one minute", time.Minute, [][]int{{0, 1}, {2}, {3}, {4}, {5}}},
+		{"measured from first call", 90 * time.Second, [][]int{{0, 1, 3}, {2}, {4}, {5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := duplicateClusters(records, tt.window); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("duplicateClusters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeptIndex(t *testing.T) {
+	base := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	records := []CallRecord{
+		dupRecord("E1", "1", base, 30),
+		dupRecord("E1", "1", base, 60),
+		dupRecord("E1", "1", base, 60),
+		dupRecord("E1", "1", base, 10),
+	}
+
+	tests := []struct {
+		name    string
+		cluster []int
+		want    int
+	}{
+		{"single", []int{3}, 3},
+		{"longest wins", []int{0, 1, 3}, 1},
+		{"earliest on ties", []int{0, 1, 2}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keptIndex(records, tt.cluster); got != tt.want {
+				t.Errorf("keptIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupeCallRecords(t *testing.T) {
+	base := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	records := []CallRecord{
+		dupRecord("E1", "1", base, 30),
+		dupRecord("E1", "1", base.Add(10*time.Second), 45),
+		dupRecord("E1", "2", base.Add(15*time.Second), 5),
+	}
+
+	got := dedupeCallRecords(records, time.Minute)
+	want := []CallRecord{records[1], records[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dedupeCallRecords() = %v, want %v", got, want)
+	}
+	if got := dedupeCallRecords(nil, time.Minute); len(got) != 0 {
+		t.Errorf("dedupeCallRecords(nil) = %v, want empty", got)
+	}
+}
+
+func TestDuplicateConfirmToken(t *testing.T) {
+	a := duplicateConfirmToken([]interface{}{"x", "y"}, time.Minute)
+
+	tests := []struct {
+		name   string
+		ids    []interface{}
+		window time.Duration
+		same   bool
+	}{
+		{"order independent", []interface{}{"y", "x"}, time.Minute, true},
+		{"different rows", []interface{}{"x", "z"}, time.Minute, false},
+		{"fewer rows", []interface{}{"x"}, time.Minute, false},
+		{"different window", []interface{}{"x", "y"}, 2 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := duplicateConfirmToken(tt.ids, tt.window); (got == a) != tt.same {
+				t.Errorf("duplicateConfirmToken() = %q vs %q, same = %v", got, a, tt.same)
+			}
+		})
+	}
+}
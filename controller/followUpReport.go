@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ClientFollowUp is one follow-up obligation taken from the clients collection.
+// The clients collection stores no due date, so DueAt is derived from
+// AssignedAt plus FOLLOW_UP_DUE_DAYS.
+type ClientFollowUp struct {
+	ClientID     string     `json:"clientId"`
+	FollowUp     string     `json:"followUp"`
+	Number       string     `json:"number"`
+	ParentNumber string     `json:"parentNumber"`
+	AssignedAt   *time.Time `json:"assignedAt,omitempty"`
+	DueAt        *time.Time `json:"dueAt,omitempty"`
+}
+
+type StaffFollowUpReport struct {
+	Name       string `json:"name"`
+	Branch     string `json:"branch"`
+	EmployeeID string `json:"employeeId"`
+	Profile    string `json:"profile"`
+	FollowUps  int    `json:"followUps"`
+	Called     int    `json:"called"`
+	Connected  int    `json:"connected"`
+	// NotCalled counts follow-ups with no call in the range; Overdue those of
+	// them already due by the end of the range (or now, if earlier).
+	NotCalled     int              `json:"notCalled"`
+	Overdue       int              `json:"overdue"`
+	CompliancePct float64          `json:"compliancePct"`
+	OverdueList   []ClientFollowUp `json:"overdueList"`
+	OverduePage   PageInfo         `json:"overduePage"`
+}
+
+// GetFollowUpComplianceReport treats every client follow-up as an obligation and
+// shows how many were called in the range, listing the overdue ones.
+func GetFollowUpComplianceReport(c *fiber.Ctx) error {
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	page, limit := queryPage(c)
+
+	staffList, err := fetchReportStaff(staffFilterFromQuery(c.Query("employeeId"), c.Query("branch"), c.Query("profile")))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	callLogs := loadCallSources().get(providerCallLogs)
+
+	asOf := endOfDay
+	if now := time.Now().UTC(); now.Before(asOf) {
+		asOf = now
+	}
+	dueAfter := time.Duration(config.FollowUpDueDays()) * 24 * time.Hour
+
+	finalReport := []StaffFollowUpReport{}
+	for _, s := range staffList {
+		followUps, err := (&ClientLeadsController{}).GetClientFollowUpsByEmployeeID(s.EmployeeID)
+		if err != nil {
+			fmt.Println("Error fetching follow ups:", err)
+		}
+
+		var numbers []string
+		for _, f := range followUps {
+			numbers = append(numbers, f.numbers()...)
+		}
+		called := getNumberCallStats(callLogs, s, removeDuplicates(numbers), startOfDay, endOfDay)
+
+		report := StaffFollowUpReport{
+			Name:       s.Name,
+			Branch:     s.Branch,
+			EmployeeID: s.EmployeeID,
+			Profile:    s.Profile,
+			FollowUps:  len(followUps),
+		}
+
+		notCalled := classifyFollowUps(followUps, called, &report)
+		overdue := overdueFollowUps(notCalled, dueAfter, asOf)
+		report.Overdue = len(overdue)
+		report.CompliancePct = utils.Percent(float64(report.Called), float64(report.FollowUps))
+
+		from, to := pageBounds(len(overdue), page, limit)
+		report.OverdueList = overdue[from:to]
+		report.OverduePage = PageInfo{Page: page, Limit: limit, Total: len(overdue)}
+
+		finalReport = append(finalReport, report)
+	}
+
+	sort.SliceStable(finalReport, func(i, j int) bool {
+		if finalReport[i].Branch == finalReport[j].Branch {
+			return finalReport[i].Name < finalReport[j].Name
+		}
+		return finalReport[i].Branch < finalReport[j].Branch
+	})
+
+	return c.JSON(finalReport)
+}
+
+// classifyFollowUps counts the follow-ups called and connected in report and
+// returns the ones with no call to any of their numbers.
+func classifyFollowUps(followUps []ClientFollowUp, called map[string]NumberCallSummary, report *StaffFollowUpReport) []ClientFollowUp {
+	notCalled := []ClientFollowUp{}
+	for _, f := range followUps {
+		attempted, connected := false, false
+		for _, number := range f.numbers() {
+			if stat, ok := called[number]; ok {
+				attempted = true
+				if stat.Connected > 0 {
+					connected = true
+				}
+			}
+		}
+
+		if !attempted {
+			notCalled = append(notCalled, f)
+			continue
+		}
+		report.Called++
+		if connected {
+			report.Connected++
+		}
+	}
+
+	report.NotCalled = len(notCalled)
+	return notCalled
+}
+
+// overdueFollowUps sets DueAt on the follow-ups not called and returns those
+// due by asOf, oldest due first. Follow-ups without an assignment time are
+// always treated as due.
+func overdueFollowUps(notCalled []ClientFollowUp, dueAfter time.Duration, asOf time.Time) []ClientFollowUp {
+	overdue := []ClientFollowUp{}
+	for _, f := range notCalled {
+		if f.AssignedAt != nil {
+			due := f.AssignedAt.Add(dueAfter)
+			f.DueAt = &due
+			if due.After(asOf) {
+				continue
+			}
+		}
+		overdue = append(overdue, f)
+	}
+
+	sort.SliceStable(overdue, func(i, j int) bool {
+		if overdue[i].DueAt == nil || overdue[j].DueAt == nil {
+			return overdue[i].DueAt == nil && overdue[j].DueAt != nil
+		}
+		return overdue[i].DueAt.Before(*overdue[j].DueAt)
+	})
+	return overdue
+}
+
+// followUpAssignedAt reads when a client was assigned, falling back to the
+// creation time of an ObjectID _id.
+func followUpAssignedAt(doc bson.M, field string) *time.Time {
+	if t := anyToTime(doc[field]); !t.IsZero() {
+		return &t
+	}
+	if id, ok := doc["_id"].(primitive.ObjectID); ok && !id.IsZero() {
+		t := id.Timestamp().UTC()
+		return &t
+	}
+	return nil
+}
+
+// numbers returns every number a call to which satisfies the follow-up.
+func (f ClientFollowUp) numbers() []string {
+	var numbers []string
+	for _, n := range []string{f.FollowUp, f.Number, f.ParentNumber} {
+		if n != "" {
+			numbers = append(numbers, n)
+		}
+	}
+	return removeDuplicates(numbers)
+}
+
+// GetClientFollowUpsByEmployeeID returns the clients of an employee that carry a follow_up entry
+func (c *ClientLeadsController) GetClientFollowUpsByEmployeeID(employeeID string) ([]ClientFollowUp, error) {
+	clientCollection := config.GetCollection("ZoomDB", "clients")
+	assignedField := config.FollowUpAssignedField()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := clientCollection.Find(ctx,
+		bson.M{
+			"employeeId": employeeID,
+			"follow_up":  bson.M{"$exists": true, "$nin": bson.A{nil, ""}},
+		},
+		options.Find().SetProjection(bson.M{"follow_up": 1, "parentNumber": 1, "number": 1, assignedField: 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []bson.M
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	var followUps []ClientFollowUp
+	for _, doc := range results {
+		followUps = append(followUps, ClientFollowUp{
+			ClientID:     fmt.Sprintf("%v", doc["_id"]),
+			FollowUp:     leadNumberString(doc["follow_up"]),
+			Number:       leadNumberString(doc["number"]),
+			ParentNumber: leadNumberString(doc["parentNumber"]),
+			AssignedAt:   followUpAssignedAt(doc, assignedField),
+		})
+	}
+
+	return followUps, nil
+}
+
+// leadNumberString converts a stored phone number (string or numeric) to its string form.
+func leadNumberString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case int, int32, int64:
+		return fmt.Sprintf("%d", v)
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestClassifyFollowUps(t *testing.T) {
+	followUps := []ClientFollowUp{
+		{ClientID: "a", FollowUp: "111", Number: "111"},
+		{ClientID: "b", FollowUp: "222", ParentNumber: "333"},
+		{ClientID: "c", FollowUp: "444"},
+		{ClientID: "d", FollowUp: "555", Number: "666"},
+	}
+	called := map[string]NumberCallSummary{
+		"111": {Number: "111", Attempts: 1, Connected: 1},
+		"333": {Number: "333", Attempts: 2, Connected: 0},
+		"666": {Number: "666", Attempts: 1, Connected: 1},
+	}
+
+	report := StaffFollowUpReport{FollowUps: len(followUps)}
+	notCalled := classifyFollowUps(followUps, called, &report)
+
+	if report.Called != 3 || report.Connected != 2 || report.NotCalled != 1 {
+		t.Errorf("report = %+v, want 3 called, 2 connected, 1 not called", report)
+	}
+	if len(notCalled) != 1 || notCalled[0].ClientID != "c" {
+		t.Errorf("notCalled = %+v, want only client c", notCalled)
+	}
+}
+
+func TestClientFollowUpNumbers(t *testing.T) {
+	tests := []struct {
+		followUp ClientFollowUp
+		want     []string
+	}{
+		{ClientFollowUp{FollowUp: "111", Number: "111", ParentNumber: "222"}, []string{"111", "222"}},
+		{ClientFollowUp{Number: "333"}, []string{"333"}},
+		{ClientFollowUp{}, []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.followUp.numbers(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v.numbers() = %v, want %v", tt.followUp, got, tt.want)
+		}
+	}
+}
+
+func TestLeadNumberString(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{"9876543210", "9876543210"},
+		{int32(12345), "12345"},
+		{int64(9876543210), "9876543210"},
+		{float64(9876543210), "9876543210"},
+		{nil, ""},
+		{true, ""},
+	}
+
+	for _, tt := range tests {
+		if got := leadNumberString(tt.in); got != tt.want {
+			t.Errorf("leadNumberString(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOverdueFollowUps(t *testing.T) {
+	asOf := time.Date(2026, 3, 10, 23, 59, 59, 0, time.UTC)
+	at := func(day int) *time.Time {
+		t := time.Date(2026, 3, day, 9, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	notCalled := []ClientFollowUp{
+		{ClientID: "recent", AssignedAt: at(9)},
+		{ClientID: "old", AssignedAt: at(1)},
+		{ClientID: "undated"},
+		{ClientID: "due today", AssignedAt: at(8)},
+	}
+
+	got := overdueFollowUps(notCalled, 48*time.Hour, asOf)
+
+	var ids []string
+	for _, f := range got {
+		ids = append(ids, f.ClientID)
+	}
+	want := []string{"undated", "old", "due today"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("overdueFollowUps() = %v, want %v", ids, want)
+	}
+	if got[1].DueAt == nil || !got[1].DueAt.Equal(*at(3)) {
+		t.Errorf("DueAt = %v, want %v", got[1].DueAt, at(3))
+	}
+	if got[0].DueAt != nil {
+		t.Errorf("undated DueAt = %v, want nil", got[0].DueAt)
+	}
+
+	if got := overdueFollowUps(notCalled, 0, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)); len(got) != 1 {
+		t.Errorf("overdueFollowUps() before any assignment = %d, want only the undated one", len(got))
+	}
+}
+
+func TestFollowUpAssignedAt(t *testing.T) {
+	assigned := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	id := primitive.NewObjectIDFromTimestamp(time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		doc  bson.M
+		want *time.Time
+	}{
+		{"assigned field", bson.M{"_id": id, "createdAt": primitive.NewDateTimeFromTime(assigned)}, &assigned},
+		{"string date", bson.M{"createdAt": "2026-03-02 10:00:00"}, &assigned},
+		{"object id fallback", bson.M{"_id": id}, func() *time.Time { t := id.Timestamp().UTC(); return &t }()},
+		{"nothing", bson.M{"_id": "C-1"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := followUpAssignedAt(tt.doc, "createdAt")
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("followUpAssignedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FunnelStages is the webinar funnel for one staff member or branch over one window.
+type FunnelStages struct {
+	Attendees     int              `json:"attendees"`
+	Intrested     int              `json:"intrested"`
+	Registrations int              `json:"registrations"`
+	SalesL1       int              `json:"salesL1"`
+	SalesL2L3     int              `json:"salesL2L3"`
+	Conversion    FunnelConversion `json:"conversion"`
+}
+
+// FunnelConversion holds stage-to-stage conversion rates as percentages.
+type FunnelConversion struct {
+	AttendeeToIntrested     float64 `json:"attendeeToIntrested"`
+	IntrestedToRegistration float64 `json:"intrestedToRegistration"`
+	RegistrationToL1        float64 `json:"registrationToL1"`
+	RegistrationToL2L3      float64 `json:"registrationToL2L3"`
+	AttendeeToL1            float64 `json:"attendeeToL1"`
+}
+
+type StaffFunnel struct {
+	Name       string       `json:"name"`
+	Branch     string       `json:"branch"`
+	EmployeeID string       `json:"employeeId"`
+	Profile    string       `json:"profile"`
+	Range      FunnelStages `json:"range"`
+	Lifetime   FunnelStages `json:"lifetime"`
+}
+
+type BranchFunnel struct {
+	Branch   string       `json:"branch"`
+	Range    FunnelStages `json:"range"`
+	Lifetime FunnelStages `json:"lifetime"`
+}
+
+// GetFunnelReport presents attendees → interested → registrations → L1/L2L3
+// sales per staff and per branch, for the range and lifetime (the total window).
+// A lead credited to several staff of one branch counts once in that branch.
+func GetFunnelReport(c *fiber.Ctx) error {
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	totalWindow, err := queryTotalWindow(c, endOfDay)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid totalWindow. Use all, fytd, year or rolling with months"})
+	}
+
+	staffList, err := fetchReportStaff(staffFilterFromQuery(c.Query("employeeId"), c.Query("branch"), c.Query("profile")))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	aliases := loadAliasRegistry()
+
+	rangeFilter := bson.M{"Date of Enrollment": bson.M{"$gte": startOfDay, "$lte": endOfDay}}
+	totalFilter := bson.M{}
+	if totalWindow.Bounded() {
+		totalFilter["Date of Enrollment"] = bson.M{"$gte": totalWindow.Start, "$lte": totalWindow.End}
+	}
+
+	staffFunnels := []StaffFunnel{}
+	branches := map[string]*BranchFunnel{}
+	branchSales := map[string]*branchLedger{}
+
+	for _, s := range staffList {
+		teams := aliases.names(s, aliasSourceAttendees)
+		salesNames := aliases.names(s, aliasSourceSales)
+
+		var rangeStages, lifetimeStages FunnelStages
+		attendees := getAttendeeMetrics(teams, startOfDay, endOfDay, totalWindow, false)
+		rangeStages.Attendees, lifetimeStages.Attendees = attendees.Attendees, attendees.TotalAttendees
+		rangeStages.Intrested, lifetimeStages.Intrested = attendees.Intrested, attendees.TotalIntrested
+		rangeStages.Registrations, lifetimeStages.Registrations = attendees.Registration, attendees.TotalRegistration
+
+		identity := newSalesIdentity(salesNames)
+		unparsed := map[string]UnparsedAmount{}
+		inRange := identity.revenueSales(fetchSalesLeads(salesNames, rangeFilter), unparsed)
+		inTotal := identity.revenueSales(fetchSalesLeads(salesNames, totalFilter), unparsed)
+		rangeStages.SalesL1, rangeStages.SalesL2L3 = countFunnelSales(inRange)
+		lifetimeStages.SalesL1, lifetimeStages.SalesL2L3 = countFunnelSales(inTotal)
+
+		rangeStages.computeConversion()
+		lifetimeStages.computeConversion()
+
+		staffFunnels = append(staffFunnels, StaffFunnel{
+			Name:       s.Name,
+			Branch:     s.Branch,
+			EmployeeID: s.EmployeeID,
+			Profile:    s.Profile,
+			Range:      rangeStages,
+			Lifetime:   lifetimeStages,
+		})
+
+		branch, ok := branches[s.Branch]
+		if !ok {
+			branch = &BranchFunnel{Branch: s.Branch}
+			branches[s.Branch] = branch
+		}
+		branch.Range.add(rangeStages)
+		branch.Lifetime.add(lifetimeStages)
+
+		ledger, ok := branchSales[s.Branch]
+		if !ok {
+			ledger = &branchLedger{inRange: newRevenueLedger(), inTotal: newRevenueLedger()}
+			branchSales[s.Branch] = ledger
+		}
+		ledger.inRange.add(inRange)
+		ledger.inTotal.add(inTotal)
+	}
+
+	sort.SliceStable(staffFunnels, func(i, j int) bool {
+		if staffFunnels[i].Branch == staffFunnels[j].Branch {
+			return staffFunnels[i].Name < staffFunnels[j].Name
+		}
+		return staffFunnels[i].Branch < staffFunnels[j].Branch
+	})
+
+	branchFunnels := []BranchFunnel{}
+	for name, b := range branches {
+		// Staff sales were summed above; a lead credited to several staff of
+		// the branch counts once, as in the revenue report.
+		b.Range.SalesL1, b.Range.SalesL2L3 = countFunnelSales(branchSales[name].inRange.sales())
+		b.Lifetime.SalesL1, b.Lifetime.SalesL2L3 = countFunnelSales(branchSales[name].inTotal.sales())
+		b.Range.computeConversion()
+		b.Lifetime.computeConversion()
+		branchFunnels = append(branchFunnels, *b)
+	}
+	sort.Slice(branchFunnels, func(i, j int) bool {
+		return branchFunnels[i].Branch < branchFunnels[j].Branch
+	})
+
+	return c.JSON(fiber.Map{
+		"staff":       staffFunnels,
+		"branches":    branchFunnels,
+		"totalWindow": totalWindow,
+	})
+}
+
+// countFunnelSales counts the sales giving L1 and L2/L3 credit.
+func countFunnelSales(sales []revenueSale) (l1, l2l3 int) {
+	for _, sale := range sales {
+		if sale.credit.L1.Sales > 0 {
+			l1++
+		}
+		if sale.credit.L2L3.Sales > 0 {
+			l2l3++
+		}
+	}
+	return l1, l2l3
+}
+
+// add sums another funnel's stage counts into f.
+func (f *FunnelStages) add(other FunnelStages) {
+	f.Attendees += other.Attendees
+	f.Intrested += other.Intrested
+	f.Registrations += other.Registrations
+	f.SalesL1 += other.SalesL1
+	f.SalesL2L3 += other.SalesL2L3
+}
+
+func (f *FunnelStages) computeConversion() {
+	f.Conversion = FunnelConversion{
+		AttendeeToIntrested:     utils.Percent(float64(f.Intrested), float64(f.Attendees)),
+		IntrestedToRegistration: utils.Percent(float64(f.Registrations), float64(f.Intrested)),
+		RegistrationToL1:        utils.Percent(float64(f.SalesL1), float64(f.Registrations)),
+		RegistrationToL2L3:      utils.Percent(float64(f.SalesL2L3), float64(f.Registrations)),
+		AttendeeToL1:            utils.Percent(float64(f.SalesL1), float64(f.Attendees)),
+	}
+}
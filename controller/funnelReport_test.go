@@ -0,0 +1,79 @@
+package controller
+
+import "testing"
+
+func TestFunnelStagesAdd(t *testing.T) {
+	f := FunnelStages{Attendees: 10, Intrested: 4, Registrations: 2, SalesL1: 1}
+	f.add(FunnelStages{Attendees: 5, Intrested: 1, Registrations: 1, SalesL1: 1, SalesL2L3: 2})
+
+	want := FunnelStages{Attendees: 15, Intrested: 5, Registrations: 3, SalesL1: 2, SalesL2L3: 2}
+	if f != want {
+		t.Errorf("add() = %+v, want %+v", f, want)
+	}
+}
+
+func TestFunnelStagesComputeConversion(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages FunnelStages
+		want   FunnelConversion
+	}{
+		{"empty funnel", FunnelStages{}, FunnelConversion{}},
+		{
+			"full funnel",
+			FunnelStages{Attendees: 200, Intrested: 50, Registrations: 20, SalesL1: 5, SalesL2L3: 3},
+			FunnelConversion{
+				AttendeeToIntrested:     25,
+				IntrestedToRegistration: 40,
+				RegistrationToL1:        25,
+				RegistrationToL2L3:      15,
+				AttendeeToL1:            2.5,
+			},
+		},
+		{
+			"no registrations",
+			FunnelStages{Attendees: 3, Intrested: 1, SalesL1: 1},
+			FunnelConversion{AttendeeToIntrested: 33.33, AttendeeToL1: 33.33},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.stages.computeConversion()
+			if tt.stages.Conversion != tt.want {
+				t.Errorf("computeConversion() = %+v, want %+v", tt.stages.Conversion, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountFunnelSalesBranchDedup(t *testing.T) {
+	sale := func(key string, l1, l2l3 bool) revenueSale {
+		s := revenueSale{key: key}
+		if l1 {
+			s.credit.L1.Sales = 1
+		}
+		if l2l3 {
+			s.credit.L2L3.Sales = 1
+		}
+		return s
+	}
+
+	// Lead "a" is credited to both staff of the branch, L1 to one and L2/L3
+	// to the other; it must count once under each role.
+	first := []revenueSale{sale("a", true, false), sale("b", true, false)}
+	second := []revenueSale{sale("a", false, true), sale("c", false, true)}
+
+	ledger := newRevenueLedger()
+	ledger.add(first)
+	ledger.add(second)
+	ledger.add(first)
+
+	l1, l2l3 := countFunnelSales(ledger.sales())
+	if l1 != 2 || l2l3 != 2 {
+		t.Errorf("branch sales = %d L1, %d L2/L3, want 2 and 2", l1, l2l3)
+	}
+
+	if l1, l2l3 := countFunnelSales(first); l1 != 2 || l2l3 != 0 {
+		t.Errorf("staff sales = %d L1, %d L2/L3, want 2 and 0", l1, l2l3)
+	}
+}
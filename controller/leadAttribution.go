@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/config"
+	"sort"
+	"strings"
+)
+
+const (
+	leadSourceAdvising = "advising"
+	leadSourceCRM      = "crm"
+	leadSourceClient   = "client"
+	leadSourceDialer   = "dialer"
+)
+
+const (
+	attributionShared    = "shared"
+	attributionExclusive = "exclusive"
+	attributionSplit     = "split"
+)
+
+const (
+	blockDiler   = "diler"
+	blockCRM     = "crm"
+	blockAdvisor = "advisor"
+)
+
+var leadSources = []string{leadSourceAdvising, leadSourceCRM, leadSourceClient, leadSourceDialer}
+
+// leadSourceBlock maps each lead source to the report block it feeds.
+var leadSourceBlock = map[string]string{
+	leadSourceAdvising: blockAdvisor,
+	leadSourceCRM:      blockCRM,
+	leadSourceClient:   blockDiler,
+	leadSourceDialer:   blockDiler,
+}
+
+// LeadOverlap summarises numbers that belong to more than one lead source.
+type LeadOverlap struct {
+	Mode          string         `json:"mode"`
+	TotalNumbers  int            `json:"totalNumbers"`
+	SharedNumbers int            `json:"sharedNumbers"`
+	Pairs         map[string]int `json:"pairs"`
+}
+
+// leadAttribution is the per-block number list after applying the attribution mode.
+type leadAttribution struct {
+	Blocks  map[string][]string
+	Weights map[string]float64
+	Overlap LeadOverlap
+}
+
+func validAttributionMode(mode string) bool {
+	switch mode {
+	case attributionShared, attributionExclusive, attributionSplit:
+		return true
+	}
+	return false
+}
+
+// attributionPriority returns the configured source order, with any source the
+// config left out appended in the default order.
+func attributionPriority() []string {
+	var priority []string
+	seen := map[string]bool{}
+	for _, source := range config.LeadAttributionPriority() {
+		if _, ok := leadSourceBlock[source]; ok && !seen[source] {
+			priority = append(priority, source)
+			seen[source] = true
+		}
+	}
+	for _, source := range leadSources {
+		if !seen[source] {
+			priority = append(priority, source)
+		}
+	}
+	return priority
+}
+
+func (l staffLeadNumbers) bySource() map[string][]string {
+	return map[string][]string{
+		leadSourceAdvising: l.Advising,
+		leadSourceCRM:      l.CRM,
+		leadSourceClient:   l.Client,
+		leadSourceDialer:   l.Dialer,
+	}
+}
+
+// attributeLeads decides which report block(s) each assigned number counts in.
+//
+//   - shared: a number counts in every block it belongs to (legacy behaviour)
+//   - exclusive: a number counts only in the block of its highest priority source
+//   - split: a number counts in every block, with weight 1/n for n blocks
+func attributeLeads(leads staffLeadNumbers, mode string) leadAttribution {
+	priority := attributionPriority()
+	sources := leads.bySource()
+
+	// Which sources each number belongs to, in priority order
+	owners := map[string][]string{}
+	var order []string
+	for _, source := range priority {
+		for _, number := range sources[source] {
+			if _, ok := owners[number]; !ok {
+				order = append(order, number)
+			}
+			if !containsString(owners[number], source) {
+				owners[number] = append(owners[number], source)
+			}
+		}
+	}
+
+	result := leadAttribution{
+		Blocks: map[string][]string{blockDiler: {}, blockCRM: {}, blockAdvisor: {}},
+		Overlap: LeadOverlap{
+			Mode:         mode,
+			TotalNumbers: len(order),
+			Pairs:        map[string]int{},
+		},
+	}
+	if mode == attributionSplit {
+		result.Weights = map[string]float64{}
+	}
+
+	for _, number := range order {
+		numberSources := owners[number]
+
+		if len(numberSources) > 1 {
+			result.Overlap.SharedNumbers++
+			for i := 0; i < len(numberSources); i++ {
+				for j := i + 1; j < len(numberSources); j++ {
+					result.Overlap.Pairs[overlapPairKey(numberSources[i], numberSources[j])]++
+				}
+			}
+		}
+
+		var blocks []string
+		for _, source := range numberSources {
+			if !containsString(blocks, leadSourceBlock[source]) {
+				blocks = append(blocks, leadSourceBlock[source])
+			}
+		}
+
+		if mode == attributionExclusive {
+			blocks = blocks[:1]
+		}
+		for _, block := range blocks {
+			result.Blocks[block] = append(result.Blocks[block], number)
+		}
+		if mode == attributionSplit {
+			result.Weights[number] = 1 / float64(len(blocks))
+		}
+	}
+
+	return result
+}
+
+func overlapPairKey(a, b string) string {
+	pair := []string{a, b}
+	sort.Strings(pair)
+	return strings.Join(pair, "+")
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
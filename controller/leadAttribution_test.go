@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAttributionPriority(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want []string
+	}{
+		{"default", "", []string{leadSourceAdvising, leadSourceCRM, leadSourceClient, leadSourceDialer}},
+		{"custom order", "dialer, CRM", []string{leadSourceDialer, leadSourceCRM, leadSourceAdvising, leadSourceClient}},
+		{"unknown and repeated sources dropped", "crm,bogus,crm", []string{leadSourceCRM, leadSourceAdvising, leadSourceClient, leadSourceDialer}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LEAD_ATTRIBUTION_PRIORITY", tt.env)
+			if got := attributionPriority(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attributionPriority() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttributeLeads(t *testing.T) {
+	t.Setenv("LEAD_ATTRIBUTION_PRIORITY", "")
+
+	// 111 is advising+crm, 222 is crm+client, 333 is client+dialer (same block), 444 is dialer only
+	leads := staffLeadNumbers{
+		Advising: []string{"111"},
+		CRM:      []string{"111", "222"},
+		Client:   []string{"222", "333"},
+		Dialer:   []string{"333", "444"},
+	}
+
+	tests := []struct {
+		mode    string
+		blocks  map[string][]string
+		weights map[string]float64
+	}{
+		{
+			mode: attributionShared,
+			blocks: map[string][]string{
+				blockAdvisor: {"111"},
+				blockCRM:     {"111", "222"},
+				blockDiler:   {"222", "333", "444"},
+			},
+		},
+		{
+			mode: attributionExclusive,
+			blocks: map[string][]string{
+				blockAdvisor: {"111"},
+				blockCRM:     {"222"},
+				blockDiler:   {"333", "444"},
+			},
+		},
+		{
+			mode: attributionSplit,
+			blocks: map[string][]string{
+				blockAdvisor: {"111"},
+				blockCRM:     {"111", "222"},
+				blockDiler:   {"222", "333", "444"},
+			},
+			weights: map[string]float64{"111": 0.5, "222": 0.5, "333": 1, "444": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got := attributeLeads(leads, tt.mode)
+
+			for block, want := range tt.blocks {
+				if !reflect.DeepEqual(got.Blocks[block], want) {
+					t.Errorf("Blocks[%s] = %v, want %v", block, got.Blocks[block], want)
+				}
+			}
+			if !reflect.DeepEqual(got.Weights, tt.weights) {
+				t.Errorf("Weights = %v, want %v", got.Weights, tt.weights)
+			}
+
+			overlap := got.Overlap
+			if overlap.Mode != tt.mode || overlap.TotalNumbers != 4 || overlap.SharedNumbers != 3 {
+				t.Errorf("Overlap = %+v, want mode %s with 4 numbers, 3 shared", overlap, tt.mode)
+			}
+			wantPairs := map[string]int{"advising+crm": 1, "client+crm": 1, "client+dialer": 1}
+			if !reflect.DeepEqual(overlap.Pairs, wantPairs) {
+				t.Errorf("Overlap.Pairs = %v, want %v", overlap.Pairs, wantPairs)
+			}
+		})
+	}
+}
+
+func TestValidAttributionMode(t *testing.T) {
+	for mode, want := range map[string]bool{"shared": true, "exclusive": true, "split": true, "": false, "Split": false} {
+		if got := validAttributionMode(mode); got != want {
+			t.Errorf("validAttributionMode(%q) = %v, want %v", mode, got, want)
+		}
+	}
+}
+
+func TestRepeatDialCounterApplyWeights(t *testing.T) {
+	counter := newRepeatDialCounter()
+	counter.add("111", 60)
+	counter.add("111", 0)
+	counter.add("222", 30)
+
+	var block ReportBlock
+	counter.applyWeights(&block, map[string]float64{"111": 0.5})
+
+	// 111: 2 attempts × 0.5, 60s × 0.5; 222 is missing from weights and counts in full
+	if block.WeightedCount != 2 {
+		t.Errorf("WeightedCount = %v, want 2", block.WeightedCount)
+	}
+	if block.WeightedDuration != 60 {
+		t.Errorf("WeightedDuration = %v, want 60", block.WeightedDuration)
+	}
+
+	var unweighted ReportBlock
+	counter.applyWeights(&unweighted, nil)
+	if unweighted.WeightedCount != 0 || unweighted.WeightedDuration != 0 {
+		t.Errorf("nil weights should leave weighted totals empty, got %+v", unweighted)
+	}
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestStaffLeadNumbersAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		leads  staffLeadNumbers
+		dialer []string
+		all    []string
+	}{
+		{
+			name:   "empty",
+			leads:  staffLeadNumbers{},
+			dialer: []string{},
+			all:    []string{},
+		},
+		{
+			name: "numbers shared between sources listed once",
+			leads: staffLeadNumbers{
+				Advising: []string{"444", "111"},
+				CRM:      []string{"333", "222"},
+				Client:   []string{"111", "222"},
+				Dialer:   []string{"222", "555"},
+			},
+			dialer: []string{"111", "222", "555"},
+			all:    []string{"111", "222", "555", "333", "444"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.leads.dialerBlock(); !reflect.DeepEqual(got, tt.dialer) {
+				t.Errorf("dialerBlock() = %v, want %v", got, tt.dialer)
+			}
+			if got := tt.leads.all(); !reflect.DeepEqual(got, tt.all) {
+				t.Errorf("all() = %v, want %v", got, tt.all)
+			}
+		})
+	}
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want []string
+	}{
+		{nil, []string{}},
+		{[]string{"a"}, []string{"a"}},
+		{[]string{"b", "a", "b", "c", "a"}, []string{"b", "a", "c"}},
+	}
+
+	for _, tt := range tests {
+		if got := removeDuplicates(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("removeDuplicates(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIncompleteLeadSources(t *testing.T) {
+	failed := &leadSourceError{failed: map[string]error{
+		leadSourceDialer: errors.New("timeout"),
+		leadSourceCRM:    errors.New("invalid employeeId"),
+	}}
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"no error", nil, nil},
+		{"failed sources", failed, []string{"crm", "dialer"}},
+		{"wrapped", fmt.Errorf("staff E1: %w", failed), []string{"crm", "dialer"}},
+		{"other error", errors.New("boom"), []string{"all"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := incompleteLeadSources(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("incompleteLeadSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	want := "lead sources failed: crm: invalid employeeId; dialer: timeout"
+	if got := failed.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LeaderboardEntry is one staff member's place on the sales leaderboard.
+// RankChange is positive when the staff member moved up since the previous period.
+type LeaderboardEntry struct {
+	Rank           int            `json:"rank"`
+	PreviousRank   int            `json:"previousRank"`
+	RankChange     int            `json:"rankChange"`
+	Name           string         `json:"name"`
+	Branch         string         `json:"branch"`
+	EmployeeID     string         `json:"employeeId"`
+	Profile        string         `json:"profile"`
+	Sales          map[string]int `json:"sales"`
+	Credit         SalesCredit    `json:"credit"`
+	PreviousCredit SalesCredit    `json:"previousCredit"`
+}
+
+// GetSalesLeaderboard ranks staff by weighted sales credit for the range, with
+// the rank change against the previous period of the same length. ?top=N keeps
+// everyone ranked N or better, so ties at the cut-off are not dropped.
+func GetSalesLeaderboard(c *fiber.Ctx) error {
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	top := c.QueryInt("top", 0)
+	if top < 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "top must be a positive number"})
+	}
+
+	staffList, err := fetchReportStaff(staffFilterFromQuery("", c.Query("branch"), c.Query("profile")))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	aliases := loadAliasRegistry()
+	prevStart, prevEnd := previousPeriod(startOfDay, endOfDay)
+
+	entries := []LeaderboardEntry{}
+	for _, s := range staffList {
+		names := aliases.names(s, aliasSourceSales)
+		sales, credit := getSalesReport(names, startOfDay, endOfDay)
+		_, previous := getSalesReport(names, prevStart, prevEnd)
+
+		entries = append(entries, LeaderboardEntry{
+			Name:           s.Name,
+			Branch:         s.Branch,
+			EmployeeID:     s.EmployeeID,
+			Profile:        s.Profile,
+			Sales:          sales,
+			Credit:         credit,
+			PreviousCredit: previous,
+		})
+	}
+
+	previousRanks := denseRanks(entries, func(e LeaderboardEntry) float64 { return e.PreviousCredit.Total })
+	ranks := denseRanks(entries, func(e LeaderboardEntry) float64 { return e.Credit.Total })
+	for i := range entries {
+		entries[i].Rank = ranks[i]
+		entries[i].PreviousRank = previousRanks[i]
+		entries[i].RankChange = previousRanks[i] - ranks[i]
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Rank == entries[j].Rank {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Rank < entries[j].Rank
+	})
+
+	if top > 0 {
+		cut := len(entries)
+		for i, e := range entries {
+			if e.Rank > top {
+				cut = i
+				break
+			}
+		}
+		entries = entries[:cut]
+	}
+
+	return c.JSON(fiber.Map{
+		"leaderboard": entries,
+		"previousPeriod": fiber.Map{
+			"fromDate": prevStart.Format("2006-01-02"),
+			"toDate":   prevEnd.Format("2006-01-02"),
+		},
+	})
+}
+
+// denseRanks ranks entries by score, highest first; equal scores share a rank
+// and the next score takes the following rank.
+func denseRanks(entries []LeaderboardEntry, score func(LeaderboardEntry) float64) []int {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return score(entries[order[a]]) > score(entries[order[b]])
+	})
+
+	ranks := make([]int, len(entries))
+	rank := 0
+	for i, idx := range order {
+		if i == 0 || score(entries[idx]) != score(entries[order[i-1]]) {
+			rank++
+		}
+		ranks[idx] = rank
+	}
+	return ranks
+}
+
+// previousPeriod returns the run of whole days of the same length ending just
+// before start.
+func previousPeriod(start, end time.Time) (time.Time, time.Time) {
+	days := int(end.Sub(start).Hours()/24) + 1
+	return start.AddDate(0, 0, -days), start.Add(-time.Millisecond)
+}
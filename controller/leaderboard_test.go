@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDenseRanks(t *testing.T) {
+	byTotal := func(e LeaderboardEntry) float64 { return e.Credit.Total }
+	entries := func(totals ...float64) []LeaderboardEntry {
+		list := make([]LeaderboardEntry, len(totals))
+		for i, total := range totals {
+			list[i].Credit.Total = total
+		}
+		return list
+	}
+
+	tests := []struct {
+		name    string
+		entries []LeaderboardEntry
+		want    []int
+	}{
+		{"empty", entries(), []int{}},
+		{"distinct", entries(1, 3, 2), []int{3, 1, 2}},
+		{"ties share a rank", entries(5, 3, 5, 1), []int{1, 2, 1, 3}},
+		{"all zero", entries(0, 0), []int{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := denseRanks(tt.entries, byTotal); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("denseRanks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviousPeriod(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	endOf := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 23, 59, 59, 999000000, time.UTC)
+	}
+
+	tests := []struct {
+		name               string
+		start, end         time.Time
+		wantStart, wantEnd time.Time
+	}{
+		{"one day", day(2026, time.March, 10), endOf(2026, time.March, 10), day(2026, time.March, 9), endOf(2026, time.March, 9)},
+		{"one week", day(2026, time.March, 8), endOf(2026, time.March, 14), day(2026, time.March, 1), endOf(2026, time.March, 7)},
+		{"across the year", day(2026, time.January, 1), endOf(2026, time.January, 31), day(2025, time.December, 1), endOf(2025, time.December, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := previousPeriod(tt.start, tt.end)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("previousPeriod() = %v, %v; want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
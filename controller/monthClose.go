@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ClosedPeriod is a month whose StaffReport has been frozen. While Locked,
+// /report requests for exactly that month with the options in Options return
+// Report instead of recomputing it; other options get a 409. Only /report is
+// frozen: /DailyReport, /report/revenue, /commission/statements and the other
+// reports still read the live collections for a closed month.
+type ClosedPeriod struct {
+	Month      string         `bson:"month" json:"month"`
+	Locked     bool           `bson:"locked" json:"locked"`
+	ClosedAt   time.Time      `bson:"closedAt" json:"closedAt"`
+	ClosedBy   string         `bson:"closedBy" json:"closedBy"`
+	ReopenedAt *time.Time     `bson:"reopenedAt,omitempty" json:"reopenedAt,omitempty"`
+	ReopenedBy string         `bson:"reopenedBy,omitempty" json:"reopenedBy,omitempty"`
+	Options    *ReportOptions `bson:"options,omitempty" json:"options,omitempty"`
+	Report     []StaffReport  `bson:"report" json:"report,omitempty"`
+}
+
+// ReportOptions are the /report query options a report was built with.
+type ReportOptions struct {
+	Attribution string            `bson:"attribution" json:"attribution"`
+	TotalWindow utils.TotalWindow `bson:"totalWindow" json:"totalWindow"`
+	Sessions    bool              `bson:"sessions" json:"sessions"`
+	Dedupe      bool              `bson:"dedupe" json:"dedupe"`
+	WindowSec   int               `bson:"windowSec,omitempty" json:"windowSec,omitempty"`
+}
+
+func newReportOptions(attribution string, total utils.TotalWindow, sessions, dedupe bool, window time.Duration) ReportOptions {
+	opts := ReportOptions{Attribution: attribution, TotalWindow: total, Sessions: sessions, Dedupe: dedupe}
+	if dedupe {
+		opts.WindowSec = int(window / time.Second)
+	}
+	return opts
+}
+
+// defaultReportOptions are the configured /report defaults for a month ending at end.
+func defaultReportOptions(end time.Time) (ReportOptions, error) {
+	total, err := utils.ResolveTotalWindow(config.TotalWindow(), end, config.FiscalYearStartMonth())
+	if err != nil {
+		return ReportOptions{}, err
+	}
+	return newReportOptions(config.LeadAttributionMode(), total, false, config.DedupeCalls(), config.CallDuplicateWindow()), nil
+}
+
+// equal compares options, treating window dates as instants.
+func (o ReportOptions) equal(other ReportOptions) bool {
+	a, b := o.TotalWindow, other.TotalWindow
+	return o.Attribution == other.Attribution &&
+		o.Sessions == other.Sessions &&
+		o.Dedupe == other.Dedupe &&
+		o.WindowSec == other.WindowSec &&
+		a.Kind == b.Kind && a.Months == b.Months && a.Start.Equal(b.Start) && a.End.Equal(b.End)
+}
+
+// reportOptions returns the options the period was frozen with. Periods closed
+// before options were stored used the defaults of the time, taken as today's.
+func (p ClosedPeriod) reportOptions() (ReportOptions, error) {
+	if p.Options != nil {
+		return *p.Options, nil
+	}
+	_, end, err := monthBounds(p.Month)
+	if err != nil {
+		return ReportOptions{}, err
+	}
+	return defaultReportOptions(end)
+}
+
+// monthBounds returns the first and last instant of a "YYYY-MM" month.
+func monthBounds(month string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, start.AddDate(0, 1, 0).Add(-time.Millisecond), nil
+}
+
+// closedPeriodFor returns the locked period when start and end cover exactly
+// one calendar month. A failed lookup is an error, not an open month.
+func closedPeriodFor(start, end time.Time) (ClosedPeriod, bool, error) {
+	month := start.Format("2006-01")
+	monthStart, monthEnd, _ := monthBounds(month)
+	if !start.Equal(monthStart) || !end.Equal(monthEnd) {
+		return ClosedPeriod{}, false, nil
+	}
+
+	collection := config.GetCollection("ZoomDB", "closedperiods")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var period ClosedPeriod
+	err := collection.FindOne(ctx, bson.M{"month": month, "locked": true}).Decode(&period)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ClosedPeriod{}, false, nil
+	}
+	if err != nil {
+		return ClosedPeriod{}, false, err
+	}
+	return period, true, nil
+}
+
+// ClosePeriod freezes the StaffReport of a finished month, built with the
+// configured defaults, into the closedperiods collection. Admin only.
+func ClosePeriod(c *fiber.Ctx) error {
+	var body struct {
+		Month string `json:"month"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid JSON"})
+	}
+
+	start, end, err := monthBounds(strings.TrimSpace(body.Month))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid month. Use YYYY-MM"})
+	}
+	month := start.Format("2006-01")
+	if !end.Before(time.Now().UTC()) {
+		return c.Status(400).JSON(fiber.Map{"error": "Only a finished month can be closed"})
+	}
+
+	_, locked, err := closedPeriodFor(start, end)
+	if err != nil {
+		fmt.Println("Error fetching closed period:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check closed period"})
+	}
+	if locked {
+		return c.Status(409).JSON(fiber.Map{"error": "Period is already closed", "month": month})
+	}
+
+	reportOptions, err := defaultReportOptions(end)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Invalid TOTAL_WINDOW"})
+	}
+
+	staffList, err := fetchReportStaff(nil)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	sources := loadCallSources()
+	if reportOptions.Dedupe {
+		sources = sources.withDedupe(time.Duration(reportOptions.WindowSec) * time.Second)
+	}
+
+	period := ClosedPeriod{
+		Month:    month,
+		Locked:   true,
+		ClosedAt: time.Now().UTC(),
+		ClosedBy: adminActor(c),
+		Options:  &reportOptions,
+		Report: buildCombineReport(staffList, combineReportOptions{
+			Start:           start,
+			End:             end,
+			Total:           reportOptions.TotalWindow,
+			AttributionMode: reportOptions.Attribution,
+			WithSessions:    reportOptions.Sessions,
+			Sources:         sources,
+		}),
+	}
+
+	collection := config.GetCollection("ZoomDB", "closedperiods")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err = collection.ReplaceOne(ctx, bson.M{"month": month}, period, options.Replace().SetUpsert(true))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to close period"})
+	}
+
+	return c.JSON(fiber.Map{
+		"month":    month,
+		"closedAt": period.ClosedAt,
+		"closedBy": period.ClosedBy,
+		"options":  reportOptions,
+		"staff":    len(period.Report),
+	})
+}
+
+// ReopenPeriod unlocks a closed month so /report recomputes it. Admin only;
+// the frozen figures are kept until the month is closed again.
+func ReopenPeriod(c *fiber.Ctx) error {
+	month := c.Params("month")
+	if _, _, err := monthBounds(month); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid month. Use YYYY-MM"})
+	}
+
+	collection := config.GetCollection("ZoomDB", "closedperiods")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"month": month, "locked": true},
+		bson.M{"$set": bson.M{"locked": false, "reopenedAt": now, "reopenedBy": adminActor(c)}},
+	)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to reopen period"})
+	}
+	if result.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Period is not closed", "month": month})
+	}
+
+	return c.JSON(fiber.Map{"month": month, "reopenedAt": now})
+}
+
+// GetClosedPeriods lists closed and reopened months without their reports.
+func GetClosedPeriods(c *fiber.Ctx) error {
+	collection := config.GetCollection("ZoomDB", "closedperiods")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"report": 0}).
+		SetSort(bson.D{{Key: "month", Value: -1}})
+
+	cursor, err := collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch periods"})
+	}
+	defer cursor.Close(ctx)
+
+	periods := []ClosedPeriod{}
+	if err := cursor.All(ctx, &periods); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode periods"})
+	}
+
+	return c.JSON(periods)
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/utils"
+	"testing"
+	"time"
+)
+
+func TestMonthBounds(t *testing.T) {
+	tests := []struct {
+		month     string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{"2026-02", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 23, 59, 59, 999000000, time.UTC), false},
+		{"2024-02", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 23, 59, 59, 999000000, time.UTC), false},
+		{"2025-12", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 23, 59, 59, 999000000, time.UTC), false},
+		{"2026-13", time.Time{}, time.Time{}, true},
+		{"March", time.Time{}, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		start, end, err := monthBounds(tt.month)
+		if (err != nil) != tt.wantErr || !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+			t.Errorf("monthBounds(%q) = %v, %v, %v; want %v, %v, error %v", tt.month, start, end, err, tt.wantStart, tt.wantEnd, tt.wantErr)
+		}
+	}
+}
+
+func TestReportOptionsEqual(t *testing.T) {
+	total := utils.TotalWindow{Kind: "fytd", Start: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 2, 28, 23, 59, 59, 999000000, time.UTC)}
+	frozen := newReportOptions("shared", total, false, true, 5*time.Second)
+
+	stored := total
+	stored.Start = stored.Start.In(time.FixedZone("IST", 19800))
+
+	tests := []struct {
+		name      string
+		requested ReportOptions
+		want      bool
+	}{
+		{"same options", newReportOptions("shared", total, false, true, 5*time.Second), true},
+		{"same instants in another zone", newReportOptions("shared", stored, false, true, 5*time.Second), true},
+		{"other attribution", newReportOptions("split", total, false, true, 5*time.Second), false},
+		{"other total window", newReportOptions("shared", utils.TotalWindow{Kind: "all"}, false, true, 5*time.Second), false},
+		{"with sessions", newReportOptions("shared", total, true, true, 5*time.Second), false},
+		{"without dedupe", newReportOptions("shared", total, false, false, 5*time.Second), false},
+		{"other dedupe window", newReportOptions("shared", total, false, true, 10*time.Second), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.requested.equal(frozen); got != tt.want {
+				t.Errorf("equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := newReportOptions("shared", total, false, false, 5*time.Second); got.WindowSec != 0 {
+		t.Errorf("newReportOptions() without dedupe kept WindowSec %d", got.WindowSec)
+	}
+}
+
+func TestClosedPeriodReportOptions(t *testing.T) {
+	t.Setenv("LEAD_ATTRIBUTION_MODE", "exclusive")
+	t.Setenv("TOTAL_WINDOW", "year")
+	t.Setenv("CALL_DEDUPE", "false")
+
+	stored := newReportOptions("split", utils.TotalWindow{Kind: "all"}, false, false, 0)
+	if got, err := (ClosedPeriod{Month: "2026-02", Options: &stored}).reportOptions(); err != nil || !got.equal(stored) {
+		t.Errorf("reportOptions() = %+v, %v; want stored %+v", got, err, stored)
+	}
+
+	want := newReportOptions("exclusive", utils.TotalWindow{
+		Kind:  "year",
+		Start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 2, 28, 23, 59, 59, 999000000, time.UTC),
+	}, false, false, 0)
+	if got, err := (ClosedPeriod{Month: "2026-02"}).reportOptions(); err != nil || !got.equal(want) {
+		t.Errorf("legacy reportOptions() = %+v, %v; want %+v", got, err, want)
+	}
+}
+
+func TestClosedPeriodForPartialMonth(t *testing.T) {
+	// A range that is not exactly one month never reaches the database.
+	start := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 23, 59, 59, 999e6, time.UTC)
+	if _, closed, err := closedPeriodFor(start, end); closed || err != nil {
+		t.Errorf("closedPeriodFor(partial month) = %v, %v; want false, nil", closed, err)
+	}
+}
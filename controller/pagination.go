@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PageInfo describes one page of a longer list in a report.
+type PageInfo struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+// maxPageLimit caps ?limit=, and maxPage caps ?page=, so page arithmetic
+// cannot overflow.
+const (
+	maxPageLimit = 500
+	maxPage      = 1000000
+)
+
+// queryPage reads page/limit query params, defaulting to page 1 of 50.
+// Limits above 500 are capped to 500.
+func queryPage(c *fiber.Ctx) (int, int) {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	if page > maxPage {
+		page = maxPage
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "50"))
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return page, limit
+}
+
+// pageBounds returns the slice bounds of the requested page within total
+// items. It never overflows, whatever page and limit are.
+func pageBounds(total, page, limit int) (int, int) {
+	if page < 1 || limit < 1 {
+		return 0, 0
+	}
+	if page-1 > total/limit {
+		return total, total
+	}
+	from := (page - 1) * limit
+	to := total
+	if limit < total-from {
+		to = from + limit
+	}
+	return from, to
+}
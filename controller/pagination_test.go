@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		total, page, limit int
+		from, to           int
+	}{
+		{total: 0, page: 1, limit: 50, from: 0, to: 0},
+		{total: 120, page: 1, limit: 50, from: 0, to: 50},
+		{total: 120, page: 3, limit: 50, from: 100, to: 120},
+		{total: 120, page: 4, limit: 50, from: 120, to: 120},
+		{total: 100, page: 2, limit: 50, from: 50, to: 100},
+		{total: 100, page: 3, limit: 50, from: 100, to: 100},
+		{total: 3, page: 2, limit: math.MaxInt64, from: 3, to: 3},
+		{total: 3, page: 1, limit: math.MaxInt64, from: 0, to: 3},
+		{total: 3, page: math.MaxInt64, limit: 2, from: 3, to: 3},
+		{total: 3, page: math.MaxInt64, limit: math.MaxInt64, from: 3, to: 3},
+		{total: 3, page: 0, limit: 0, from: 0, to: 0},
+	}
+
+	for _, tt := range tests {
+		from, to := pageBounds(tt.total, tt.page, tt.limit)
+		if from != tt.from || to != tt.to {
+			t.Errorf("pageBounds(%d, %d, %d) = %d, %d, want %d, %d", tt.total, tt.page, tt.limit, from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestQueryPage(t *testing.T) {
+	tests := []struct {
+		query       string
+		page, limit int
+	}{
+		{"", 1, 50},
+		{"?page=3&limit=20", 3, 20},
+		{"?page=-1&limit=0", 1, 50},
+		{"?page=x&limit=y", 1, 50},
+		{"?page=2&limit=9223372036854775807", 2, maxPageLimit},
+		{"?page=9223372036854775807&limit=10", maxPage, 10},
+	}
+
+	for _, tt := range tests {
+		app := fiber.New()
+		var page, limit int
+		app.Get("/", func(c *fiber.Ctx) error {
+			page, limit = queryPage(c)
+			return nil
+		})
+		if _, err := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil)); err != nil {
+			t.Fatal(err)
+		}
+		if page != tt.page || limit != tt.limit {
+			t.Errorf("queryPage(%q) = %d, %d, want %d, %d", tt.query, page, limit, tt.page, tt.limit)
+		}
+	}
+}
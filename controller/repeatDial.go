@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"math"
+	"sort"
+)
+
+// repeatDialTopN caps how many numbers are listed in TopRepeatedNumbers.
+const repeatDialTopN = 10
+
+// repeatDialCounter tracks attempts per phone number so a lead dialled
+// fifteen times shows up as one contact with fifteen attempts.
+type repeatDialCounter struct {
+	order   []string
+	numbers map[string]*RepeatDial
+}
+
+func newRepeatDialCounter() *repeatDialCounter {
+	return &repeatDialCounter{numbers: make(map[string]*RepeatDial)}
+}
+
+func (r *repeatDialCounter) add(number string, duration int) {
+	if number == "" {
+		return
+	}
+
+	entry, ok := r.numbers[number]
+	if !ok {
+		entry = &RepeatDial{Number: number}
+		r.numbers[number] = entry
+		r.order = append(r.order, number)
+	}
+
+	entry.Attempts++
+	entry.TotalDuration += duration
+	if duration > 0 {
+		entry.Connected++
+	}
+}
+
+// apply fills the unique-contact fields of the block.
+func (r *repeatDialCounter) apply(block *ReportBlock) {
+	block.UniqueNumbers = len(r.numbers)
+	block.UniqueConnected = 0
+	block.TopRepeatedNumbers = []RepeatDial{}
+
+	if len(r.numbers) == 0 {
+		block.AvgAttemptsPerNumber = 0
+		return
+	}
+
+	attempts := 0
+	var repeated []RepeatDial
+	for _, number := range r.order {
+		entry := r.numbers[number]
+		attempts += entry.Attempts
+		if entry.Connected > 0 {
+			block.UniqueConnected++
+		}
+		if entry.Attempts > 1 {
+			repeated = append(repeated, *entry)
+		}
+	}
+
+	// Round to two decimals for the JSON output
+	avg := float64(attempts) / float64(len(r.numbers))
+	block.AvgAttemptsPerNumber = math.Round(avg*100) / 100
+
+	sort.SliceStable(repeated, func(i, j int) bool {
+		return repeated[i].Attempts > repeated[j].Attempts
+	})
+	if len(repeated) > repeatDialTopN {
+		repeated = repeated[:repeatDialTopN]
+	}
+	block.TopRepeatedNumbers = repeated
+}
+
+// applyWeights fills the weighted totals used by split lead attribution.
+// Numbers missing from weights count in full.
+func (r *repeatDialCounter) applyWeights(block *ReportBlock, weights map[string]float64) {
+	if weights == nil {
+		return
+	}
+
+	count, duration := 0.0, 0.0
+	for _, number := range r.order {
+		entry := r.numbers[number]
+		weight, ok := weights[number]
+		if !ok {
+			weight = 1
+		}
+		count += float64(entry.Attempts) * weight
+		duration += float64(entry.TotalDuration) * weight
+	}
+
+	block.WeightedCount = math.Round(count*100) / 100
+	block.WeightedDuration = math.Round(duration*100) / 100
+}
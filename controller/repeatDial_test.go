@@ -0,0 +1,84 @@
+package controller
+
+import "testing"
+
+func TestRepeatDialCounterApply(t *testing.T) {
+	type call struct {
+		number   string
+		duration int
+	}
+	tests := []struct {
+		name            string
+		calls           []call
+		unique          int
+		uniqueConnected int
+		avgAttempts     float64
+		topNumbers      []string
+	}{
+		{name: "no calls", calls: nil},
+		{
+			name:            "blank numbers ignored",
+			calls:           []call{{"", 30}, {"9876543210", 0}},
+			unique:          1,
+			uniqueConnected: 0,
+			avgAttempts:     1,
+			topNumbers:      []string{},
+		},
+		{
+			name: "repeats ranked by attempts",
+			calls: []call{
+				{"111", 0}, {"222", 10}, {"111", 0}, {"222", 0}, {"111", 20}, {"333", 5},
+			},
+			unique:          3,
+			uniqueConnected: 3,
+			avgAttempts:     2,
+			topNumbers:      []string{"111", "222"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := newRepeatDialCounter()
+			for _, c := range tt.calls {
+				counter.add(c.number, c.duration)
+			}
+
+			var block ReportBlock
+			counter.apply(&block)
+
+			if block.UniqueNumbers != tt.unique {
+				t.Errorf("UniqueNumbers = %d, want %d", block.UniqueNumbers, tt.unique)
+			}
+			if block.UniqueConnected != tt.uniqueConnected {
+				t.Errorf("UniqueConnected = %d, want %d", block.UniqueConnected, tt.uniqueConnected)
+			}
+			if block.AvgAttemptsPerNumber != tt.avgAttempts {
+				t.Errorf("AvgAttemptsPerNumber = %v, want %v", block.AvgAttemptsPerNumber, tt.avgAttempts)
+			}
+			if len(block.TopRepeatedNumbers) != len(tt.topNumbers) {
+				t.Fatalf("TopRepeatedNumbers = %v, want %v", block.TopRepeatedNumbers, tt.topNumbers)
+			}
+			for i, number := range tt.topNumbers {
+				if block.TopRepeatedNumbers[i].Number != number {
+					t.Errorf("TopRepeatedNumbers[%d] = %s, want %s", i, block.TopRepeatedNumbers[i].Number, number)
+				}
+			}
+		})
+	}
+}
+
+func TestRepeatDialCounterTopNCap(t *testing.T) {
+	counter := newRepeatDialCounter()
+	for i := 0; i < repeatDialTopN+5; i++ {
+		number := string(rune('a' + i))
+		counter.add(number, 0)
+		counter.add(number, 0)
+	}
+
+	var block ReportBlock
+	counter.apply(&block)
+
+	if len(block.TopRepeatedNumbers) != repeatDialTopN {
+		t.Errorf("len(TopRepeatedNumbers) = %d, want %d", len(block.TopRepeatedNumbers), repeatDialTopN)
+	}
+}
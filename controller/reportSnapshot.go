@@ -0,0 +1,408 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MetricChange is one metric of one staff member that differs between snapshots.
+// From or To is nil when the metric is missing on that side.
+type MetricChange struct {
+	Metric string   `json:"metric"`
+	From   *float64 `json:"from"`
+	To     *float64 `json:"to"`
+	Delta  float64  `json:"delta"`
+}
+
+type StaffSnapshotDiff struct {
+	EmployeeID string         `json:"employeeId"`
+	Name       string         `json:"name"`
+	Changes    []MetricChange `json:"changes"`
+}
+
+// snapshotRow is one staff member's flattened numeric metrics in a snapshot.
+type snapshotRow struct {
+	name    string
+	metrics map[string]float64
+}
+
+// snapshotArrayKeys name the element field used to key arrays when flattening,
+// so daily rows compare by date rather than position. Elements sharing a key
+// (e.g. two sessions on one date) get "#2", "#3"... in array order.
+var snapshotArrayKeys = []string{"date", "number", "source", "month", "id"}
+
+// EnsureReportSnapshotIndex makes snapshot names unique, so two requests
+// saving the same name at once cannot both succeed. Run once at startup.
+func EnsureReportSnapshotIndex() error {
+	collection := config.GetCollection("ZoomDB", "reportsnapshots")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// SaveReportSnapshot persists a report response as a named snapshot when the
+// request carries ?snapshot=<name>. It runs in front of the report handlers; a
+// report that cannot be saved is answered with 500 instead of the report.
+func SaveReportSnapshot(c *fiber.Ctx) error {
+	name := strings.TrimSpace(c.Query("snapshot"))
+	if name == "" || c.Method() != fiber.MethodGet {
+		return c.Next()
+	}
+
+	if _, err := findReportSnapshot(name); err == nil {
+		return c.Status(409).JSON(fiber.Map{"error": "Snapshot name already used", "snapshot": name})
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		fmt.Println("Error checking snapshot name:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check snapshot name"})
+	}
+
+	if err := c.Next(); err != nil {
+		return err
+	}
+	if c.Response().StatusCode() != 200 {
+		return nil
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(c.Response().Body(), &payload); err != nil {
+		fmt.Println("Snapshot decode error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save snapshot", "snapshot": name})
+	}
+
+	filters := c.Queries()
+	delete(filters, "snapshot")
+
+	snapshot := models.ReportSnapshot{
+		Name:        name,
+		Path:        c.Path(),
+		Filters:     filters,
+		FromDate:    filters["fromDate"],
+		ToDate:      filters["toDate"],
+		GeneratedAt: time.Now().UTC(),
+		Payload:     payload,
+	}
+
+	collection := config.GetCollection("ZoomDB", "reportsnapshots")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, snapshot); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Snapshot name already used", "snapshot": name})
+		}
+		fmt.Println("Error saving snapshot:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save snapshot", "snapshot": name})
+	}
+
+	c.Set("X-Report-Snapshot", name)
+	return nil
+}
+
+// GetReportSnapshots lists saved snapshots without their payloads.
+func GetReportSnapshots(c *fiber.Ctx) error {
+	collection := config.GetCollection("ZoomDB", "reportsnapshots")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if path := c.Query("path"); path != "" {
+		filter["path"] = path
+	}
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"payload": 0}).
+		SetSort(bson.D{{Key: "generatedAt", Value: -1}})
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch snapshots"})
+	}
+	defer cursor.Close(ctx)
+
+	snapshots := []models.ReportSnapshot{}
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode snapshots"})
+	}
+
+	return c.JSON(snapshots)
+}
+
+// GetReportSnapshot returns one snapshot with its payload.
+func GetReportSnapshot(c *fiber.Ctx) error {
+	snapshot, err := findReportSnapshot(c.Params("name"))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return c.Status(404).JSON(fiber.Map{"error": "Snapshot not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch snapshot"})
+	}
+	return c.JSON(snapshot)
+}
+
+// DiffReportSnapshots compares two snapshots (?from=&to=) of the same report
+// per staff member and per numeric metric, listing only the metrics that moved.
+func DiffReportSnapshots(c *fiber.Ctx) error {
+	fromName, toName := c.Query("from"), c.Query("to")
+	if fromName == "" || toName == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "from and to snapshot names are required"})
+	}
+
+	from, err := findReportSnapshot(fromName)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Snapshot not found", "snapshot": fromName})
+	}
+	to, err := findReportSnapshot(toName)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Snapshot not found", "snapshot": toName})
+	}
+	if from.Path != to.Path {
+		return c.Status(400).JSON(fiber.Map{"error": "Snapshots are of different reports", "fromPath": from.Path, "toPath": to.Path})
+	}
+
+	fromRows, toRows := snapshotRows(from.Payload), snapshotRows(to.Payload)
+
+	staffDiffs := []StaffSnapshotDiff{}
+	added, removed := []string{}, []string{}
+
+	for empID := range toRows {
+		if _, ok := fromRows[empID]; !ok {
+			added = append(added, empID)
+		}
+	}
+	for empID, row := range fromRows {
+		other, ok := toRows[empID]
+		if !ok {
+			removed = append(removed, empID)
+			continue
+		}
+		changes := diffMetrics(row.metrics, other.metrics)
+		if len(changes) > 0 {
+			staffDiffs = append(staffDiffs, StaffSnapshotDiff{EmployeeID: empID, Name: other.name, Changes: changes})
+		}
+	}
+
+	sort.Slice(staffDiffs, func(i, j int) bool {
+		return staffDiffs[i].EmployeeID < staffDiffs[j].EmployeeID
+	})
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	from.Payload, to.Payload = nil, nil
+	return c.JSON(fiber.Map{
+		"from":    from,
+		"to":      to,
+		"staff":   staffDiffs,
+		"added":   added,
+		"removed": removed,
+	})
+}
+
+func findReportSnapshot(name string) (models.ReportSnapshot, error) {
+	collection := config.GetCollection("ZoomDB", "reportsnapshots")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var snapshot models.ReportSnapshot
+	err := collection.FindOne(ctx, bson.M{"name": name}).Decode(&snapshot)
+	return snapshot, err
+}
+
+// diffMetrics returns the metrics whose values differ, sorted by name.
+func diffMetrics(from, to map[string]float64) []MetricChange {
+	keys := map[string]bool{}
+	for k := range from {
+		keys[k] = true
+	}
+	for k := range to {
+		keys[k] = true
+	}
+
+	changes := []MetricChange{}
+	for metric := range keys {
+		a, inFrom := from[metric]
+		b, inTo := to[metric]
+		if inFrom && inTo && a == b {
+			continue
+		}
+
+		change := MetricChange{Metric: metric, Delta: b - a}
+		if inFrom {
+			change.From = &a
+		}
+		if inTo {
+			change.To = &b
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Metric < changes[j].Metric
+	})
+	return changes
+}
+
+// snapshotRows finds the per-staff rows in a payload (the report array itself,
+// or the first array of objects with an employeeId, e.g. "staff") and flattens
+// each into numeric metrics keyed by employeeId.
+func snapshotRows(payload interface{}) map[string]snapshotRow {
+	rows := map[string]snapshotRow{}
+
+	list, ok := asList(payload)
+	if !ok {
+		if doc, isDoc := asDoc(payload); isDoc {
+			keys := make([]string, 0, len(doc))
+			for k := range doc {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if l, isList := asList(doc[k]); isList && hasEmployeeRows(l) {
+					list, ok = l, true
+					break
+				}
+			}
+		}
+	}
+	if !ok {
+		return rows
+	}
+
+	for _, item := range list {
+		doc, isDoc := asDoc(item)
+		if !isDoc {
+			continue
+		}
+		empID, _ := doc["employeeId"].(string)
+		if empID == "" {
+			continue
+		}
+		name, _ := doc["name"].(string)
+		metrics := map[string]float64{}
+		flattenMetrics("", doc, metrics)
+		rows[empID] = snapshotRow{name: name, metrics: metrics}
+	}
+
+	return rows
+}
+
+func hasEmployeeRows(list []interface{}) bool {
+	for _, item := range list {
+		if doc, ok := asDoc(item); ok {
+			_, has := doc["employeeId"]
+			return has
+		}
+	}
+	return false
+}
+
+// flattenMetrics collects every numeric leaf of value under dotted paths.
+func flattenMetrics(prefix string, value interface{}, out map[string]float64) {
+	if n, ok := asNumber(value); ok {
+		if prefix != "" {
+			out[prefix] = n
+		}
+		return
+	}
+
+	if doc, ok := asDoc(value); ok {
+		for k, v := range doc {
+			flattenMetrics(joinMetricPath(prefix, k), v, out)
+		}
+		return
+	}
+
+	if list, ok := asList(value); ok {
+		seen := map[string]int{}
+		for i, item := range list {
+			key := fmt.Sprint(i)
+			if doc, isDoc := asDoc(item); isDoc {
+				for _, field := range snapshotArrayKeys {
+					if v, has := doc[field]; has {
+						key = fmt.Sprint(v)
+						break
+					}
+				}
+			}
+			seen[key]++
+			if n := seen[key]; n > 1 {
+				key = fmt.Sprintf("%s#%d", key, n)
+			}
+			flattenMetrics(joinMetricPath(prefix, key), item, out)
+		}
+	}
+}
+
+func joinMetricPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// asDoc accepts documents as decoded from JSON or from Mongo.
+func asDoc(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case bson.M:
+		return v, true
+	case bson.D:
+		doc := make(map[string]interface{}, len(v))
+		for _, e := range v {
+			doc[e.Key] = e.Value
+		}
+		return doc, true
+	}
+	return nil, false
+}
+
+func asList(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case bson.A:
+		return v, true
+	}
+	return nil, false
+}
+
+func asNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case primitive.Decimal128:
+		f, err := json.Number(v.String()).Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
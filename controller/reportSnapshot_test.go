@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFlattenMetrics(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  map[string]float64
+	}{
+		{"bare number has no path", 5.0, map[string]float64{}},
+		{
+			"nested documents",
+			map[string]interface{}{"calls": 3.0, "name": "Amit", "sales": bson.M{"L1": int32(2), "credit": bson.D{{Key: "total", Value: 1.5}}}},
+			map[string]float64{"calls": 3, "sales.L1": 2, "sales.credit.total": 1.5},
+		},
+		{
+			"arrays keyed by field",
+			map[string]interface{}{"daily": []interface{}{
+				map[string]interface{}{"date": "01-03-2026", "calls": 4.0},
+				map[string]interface{}{"date": "02-03-2026", "calls": 6.0},
+			}},
+			map[string]float64{"daily.01-03-2026.calls": 4, "daily.02-03-2026.calls": 6},
+		},
+		{
+			"repeated keys kept apart",
+			map[string]interface{}{"sessions": bson.A{
+				bson.M{"date": "01-03-2026", "attendees": 10.0},
+				bson.M{"date": "01-03-2026", "attendees": 12.0},
+				bson.M{"date": "01-03-2026", "attendees": 7.0},
+			}},
+			map[string]float64{"sessions.01-03-2026.attendees": 10, "sessions.01-03-2026#2.attendees": 12, "sessions.01-03-2026#3.attendees": 7},
+		},
+		{
+			"arrays without keys use positions",
+			map[string]interface{}{"scores": []interface{}{1.0, 2.0}},
+			map[string]float64{"scores.0": 1, "scores.1": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]float64{}
+			flattenMetrics("", tt.value, got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenMetrics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffMetrics(t *testing.T) {
+	from := map[string]float64{"calls": 10, "sales": 2, "dropped": 1}
+	to := map[string]float64{"calls": 12, "sales": 2, "added": 3}
+
+	ten, twelve, one, three := 10.0, 12.0, 1.0, 3.0
+	want := []MetricChange{
+		{Metric: "added", To: &three, Delta: 3},
+		{Metric: "calls", From: &ten, To: &twelve, Delta: 2},
+		{Metric: "dropped", From: &one, Delta: -1},
+	}
+	if got := diffMetrics(from, to); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffMetrics() = %+v, want %+v", got, want)
+	}
+	if got := diffMetrics(from, from); len(got) != 0 {
+		t.Errorf("diffMetrics() of equal metrics = %+v, want none", got)
+	}
+}
+
+func TestSnapshotRows(t *testing.T) {
+	row := func(empID string, calls float64) map[string]interface{} {
+		return map[string]interface{}{"employeeId": empID, "name": "Staff " + empID, "calls": calls}
+	}
+
+	tests := []struct {
+		name    string
+		payload interface{}
+		want    map[string]snapshotRow
+	}{
+		{
+			"report array",
+			[]interface{}{row("E1", 3), map[string]interface{}{"name": "no id"}, "skip"},
+			map[string]snapshotRow{"E1": {name: "Staff E1", metrics: map[string]float64{"calls": 3}}},
+		},
+		{
+			"staff inside a document",
+			map[string]interface{}{
+				"branches":    []interface{}{map[string]interface{}{"branch": "Pune", "calls": 9.0}},
+				"staff":       []interface{}{row("E2", 5)},
+				"totalWindow": map[string]interface{}{"kind": "all"},
+			},
+			map[string]snapshotRow{"E2": {name: "Staff E2", metrics: map[string]float64{"calls": 5}}},
+		},
+		{"no rows", map[string]interface{}{"total": 1.0}, map[string]snapshotRow{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snapshotRows(tt.payload); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("snapshotRows() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsNumber(t *testing.T) {
+	decimal, _ := primitive.ParseDecimal128("12.5")
+
+	tests := []struct {
+		value  interface{}
+		want   float64
+		wantOK bool
+	}{
+		{1.5, 1.5, true},
+		{float32(2), 2, true},
+		{3, 3, true},
+		{int32(4), 4, true},
+		{int64(5), 5, true},
+		{decimal, 12.5, true},
+		{"6", 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := asNumber(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("asNumber(%v) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// salesNameSeparator splits an L1 or L2/L3 entry that credits several people,
+// e.g. "Amit L1, Priya OV" or "Amit & Priya".
+var salesNameSeparator = regexp.MustCompile(`(?i)\s*(?:,|;|&|\+|\n|\band\b)\s*`)
+
+// salesIdentity is the set of normalised names one staff member is credited under.
+type salesIdentity map[string]bool
+
+// newSalesIdentity builds the identity from the staff name and its sales aliases.
+func newSalesIdentity(names []string) salesIdentity {
+	identity := salesIdentity{}
+	for _, name := range names {
+		if n := normalizeName(cleanName(name)); n != "" {
+			identity[n] = true
+		}
+	}
+	return identity
+}
+
+// credits reports whether any person named in an L1 or L2/L3 entry is this staff member.
+func (id salesIdentity) credits(value string) bool {
+	for _, name := range splitSalesNames(value) {
+		if id[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// share is this staff member's part of an entry's weight: the whole weight,
+// or an equal share of it among the names when splitNames is set.
+func (id salesIdentity) share(value string, weight float64, splitNames bool) float64 {
+	names := splitSalesNames(value)
+	matched := false
+	for _, name := range names {
+		if id[name] {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return 0
+	}
+	if splitNames {
+		return weight / float64(len(removeDuplicates(names)))
+	}
+	return weight
+}
+
+// SalesCredit is the fractional sales credit earned under the split-credit rule.
+type SalesCredit struct {
+	L1    float64 `json:"L1"`
+	L2L3  float64 `json:"L2L3"`
+	Total float64 `json:"total"`
+}
+
+// add credits one sale to c under rule. When the weights are shares of one
+// sale (summing to 1 or less) and L2/L3 names nobody, L1 takes both shares
+// (and L2/L3 does when L1 is empty), so a sale is worth the same however it
+// is split. Larger weights are full credits per role and are never combined.
+func (c *SalesCredit) add(id salesIdentity, r SalesLead, rule config.SalesCreditRule) {
+	l1Weight, l2l3Weight := rule.L1, rule.L2L3
+	if rule.L1+rule.L2L3 <= 1 {
+		switch {
+		case len(splitSalesNames(r.L2L3)) == 0:
+			l1Weight += rule.L2L3
+		case len(splitSalesNames(r.L1)) == 0:
+			l2l3Weight += rule.L1
+		}
+	}
+
+	c.L1 += id.share(r.L1, l1Weight, rule.SplitNames)
+	c.L2L3 += id.share(r.L2L3, l2l3Weight, rule.SplitNames)
+	c.Total = c.L1 + c.L2L3
+}
+
+// rounded returns c rounded for JSON output.
+func (c SalesCredit) rounded() SalesCredit {
+	return SalesCredit{L1: utils.Round2(c.L1), L2L3: utils.Round2(c.L2L3), Total: utils.Round2(c.Total)}
+}
+
+// splitSalesNames breaks an L1 or L2/L3 entry into normalised staff names,
+// dropping the L1/L2/3/OV suffixes.
+func splitSalesNames(value string) []string {
+	names := []string{}
+	for _, part := range salesNameSeparator.Split(value, -1) {
+		if name := normalizeName(cleanName(part)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// salesNamePrefilter narrows salesleads to entries mentioning any of the names.
+// The names are escaped, so it is only a prefilter; credits decides the match.
+func salesNamePrefilter(names []string) bson.M {
+	quoted := []string{}
+	for _, name := range names {
+		if name = strings.TrimSpace(cleanName(name)); name != "" {
+			quoted = append(quoted, regexp.QuoteMeta(name))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	pattern := strings.Join(quoted, "|")
+	return bson.M{"$or": []bson.M{
+		{"L1": bson.M{"$regex": pattern, "$options": "i"}},
+		{"L2/L3": bson.M{"$regex": pattern, "$options": "i"}},
+	}}
+}
+
+// fetchSalesLeads returns the sales leads that may credit any of the names,
+// narrowed further by extra (e.g. a Date of Enrollment range).
+func fetchSalesLeads(names []string, extra bson.M) []SalesLead {
+	filter := salesNamePrefilter(names)
+	if filter == nil {
+		return nil
+	}
+	for k, v := range extra {
+		filter[k] = v
+	}
+
+	collection := config.GetCollection("ZoomDB", "salesleads")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		fmt.Println("Error fetching:", err)
+		return nil
+	}
+	defer cursor.Close(ctx)
+
+	var results []SalesLead
+	if err := cursor.All(ctx, &results); err != nil {
+		fmt.Println("Decode error:", err)
+		return nil
+	}
+
+	return results
+}
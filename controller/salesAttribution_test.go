@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/config"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCleanName(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"Amit Shah L1", "Amit Shah"},
+		{"Priya L2/3", "Priya"},
+		{"Priya OV", "Priya"},
+		{" Ravi Kumar ", "Ravi Kumar"},
+		{"Olivia", "Olivia"},
+	}
+	for _, tt := range tests {
+		if got := cleanName(tt.value); got != tt.want {
+			t.Errorf("cleanName(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSalesNames(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", []string{}},
+		{"Amit Shah L1", []string{"amit shah"}},
+		{"Amit L1, Priya OV", []string{"amit", "priya"}},
+		{"Amit & Priya; Ravi + Meena", []string{"amit", "priya", "ravi", "meena"}},
+		{"Amit and Priya", []string{"amit", "priya"}},
+		{"Anand", []string{"anand"}},
+		{"Amit\nPriya L2/3", []string{"amit", "priya"}},
+		{" , Amit ,", []string{"amit"}},
+	}
+	for _, tt := range tests {
+		if got := splitSalesNames(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitSalesNames(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestNewSalesIdentity(t *testing.T) {
+	got := newSalesIdentity([]string{"Amit  Shah", "amit L1", "", "  "})
+	want := salesIdentity{"amit shah": true, "amit": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newSalesIdentity() = %v, want %v", got, want)
+	}
+}
+
+func TestSalesIdentityCredits(t *testing.T) {
+	id := newSalesIdentity([]string{"Amit Shah", "Amit S"})
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"Amit Shah L1", true},
+		{"amit s OV", true},
+		{"Priya, AMIT SHAH", true},
+		{"Amit", false},
+		{"Amit Shahi", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := id.credits(tt.value); got != tt.want {
+			t.Errorf("credits(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSalesNamePrefilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  bson.M
+	}{
+		{"no names", nil, nil},
+		{"blank names skipped", []string{" ", "L1"}, bson.M{"$or": []bson.M{
+			{"L1": bson.M{"$regex": "L1", "$options": "i"}},
+			{"L2/L3": bson.M{"$regex": "L1", "$options": "i"}},
+		}}},
+		{"escaped", []string{"Amit L1", "R. Kumar"}, bson.M{"$or": []bson.M{
+			{"L1": bson.M{"$regex": `Amit|R\. Kumar`, "$options": "i"}},
+			{"L2/L3": bson.M{"$regex": `Amit|R\. Kumar`, "$options": "i"}},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := salesNamePrefilter(tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("salesNamePrefilter(%q) = %v, want %v", tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSalesIdentityShare(t *testing.T) {
+	id := newSalesIdentity([]string{"Amit"})
+
+	tests := []struct {
+		name       string
+		value      string
+		weight     float64
+		splitNames bool
+		want       float64
+	}{
+		{"not credited", "Priya", 0.6, false, 0},
+		{"whole weight", "Amit, Priya", 0.6, false, 0.6},
+		{"split between names", "Amit, Priya, Ravi", 0.9, true, 0.3},
+		{"repeated name counts once", "Amit, Priya, Priya OV", 0.5, true, 0.25},
+		{"alone", "Amit L1", 0.6, true, 0.6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := id.share(tt.value, tt.weight, tt.splitNames); got != tt.want {
+				t.Errorf("share(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSalesCreditAdd(t *testing.T) {
+	rule := config.SalesCreditRule{L1: 0.6, L2L3: 0.4}
+	amit := newSalesIdentity([]string{"Amit"})
+
+	tests := []struct {
+		name string
+		rule config.SalesCreditRule
+		lead SalesLead
+		want SalesCredit
+	}{
+		{"both filled", rule, SalesLead{L1: "Amit", L2L3: "Priya"}, SalesCredit{L1: 0.6, Total: 0.6}},
+		{"credited in both", rule, SalesLead{L1: "Amit", L2L3: "Amit OV"}, SalesCredit{L1: 0.6, L2L3: 0.4, Total: 1}},
+		{"empty L2/L3 gives L1 full weight", rule, SalesLead{L1: "Amit L1", L2L3: " "}, SalesCredit{L1: 1, Total: 1}},
+		{"empty L1 gives L2/L3 full weight", rule, SalesLead{L2L3: "Amit"}, SalesCredit{L2L3: 1, Total: 1}},
+		{"not credited", rule, SalesLead{L1: "Priya"}, SalesCredit{}},
+		{"default weights", config.SalesCreditRule{L1: 1, L2L3: 1}, SalesLead{L1: "Amit", L2L3: "Priya"}, SalesCredit{L1: 1, Total: 1}},
+		{"default weights, L1 only", config.SalesCreditRule{L1: 1, L2L3: 1}, SalesLead{L1: "Amit"}, SalesCredit{L1: 1, Total: 1}},
+		{"default weights, L2/L3 only", config.SalesCreditRule{L1: 1, L2L3: 1}, SalesLead{L2L3: "Amit"}, SalesCredit{L2L3: 1, Total: 1}},
+		{"split names", config.SalesCreditRule{L1: 0.6, L2L3: 0.4, SplitNames: true}, SalesLead{L1: "Amit, Priya"}, SalesCredit{L1: 0.5, Total: 0.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got SalesCredit
+			got.add(amit, tt.lead, tt.rule)
+			if got.rounded() != tt.want {
+				t.Errorf("add() = %+v, want %+v", got.rounded(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SaleRecord is one salesleads record credited to a staff member, with the
+// role they were credited in: "L1", "L2L3" or "L1+L2L3".
+type SaleRecord struct {
+	ID               string  `json:"id"`
+	StudentName      string  `json:"studentName"`
+	DateOfEnrollment string  `json:"dateOfEnrollment"`
+	Source           string  `json:"source"`
+	Year             string  `json:"year"`
+	Role             string  `json:"role"`
+	L1               string  `json:"L1"`
+	L2L3             string  `json:"L2L3"`
+	Amount           float64 `json:"amount"`
+	Fee              float64 `json:"fee"`
+	Credit           float64 `json:"credit"`
+}
+
+// GetStaffSalesDrillDown lists the salesleads records behind one staff
+// member's L1/L2L3 counts for the range.
+func GetStaffSalesDrillDown(c *fiber.Ctx) error {
+	empID := c.Query("employeeId")
+	if empID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "employeeId is required"})
+	}
+
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	staffList, err := fetchReportStaff(bson.M{"employeeId": empID})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+	if len(staffList) == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Staff not found"})
+	}
+	s := staffList[0]
+
+	names := loadAliasRegistry().names(s, aliasSourceSales)
+	identity := newSalesIdentity(names)
+	rule := config.SalesCredit()
+
+	leads := fetchSalesLeads(names, bson.M{
+		"Date of Enrollment": bson.M{"$gte": startOfDay, "$lte": endOfDay},
+	})
+
+	records := []SaleRecord{}
+	count := map[string]int{"L1": 0, "L2L3": 0}
+	var credit SalesCredit
+	unparsed := []UnparsedAmount{}
+
+	for _, r := range leads {
+		l1, l2l3 := identity.credits(r.L1), identity.credits(r.L2L3)
+		role := saleRole(l1, l2l3)
+		if role == "" {
+			continue
+		}
+		if l1 {
+			count["L1"]++
+		}
+		if l2l3 {
+			count["L2L3"]++
+		}
+
+		value, invalid := saleValue(r)
+		unparsed = append(unparsed, invalid...)
+
+		var one SalesCredit
+		one.add(identity, r, rule)
+		credit.add(identity, r, rule)
+
+		records = append(records, SaleRecord{
+			ID:               salesLeadKey(r),
+			StudentName:      r.StudentName,
+			DateOfEnrollment: r.DateOfEnrollment.Format("2006-01-02"),
+			Source:           salesSourceName(r.Source),
+			Year:             r.Year,
+			Role:             role,
+			L1:               r.L1,
+			L2L3:             r.L2L3,
+			Amount:           value.Amount,
+			Fee:              value.Fee,
+			Credit:           utils.Round2(one.Total),
+		})
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].DateOfEnrollment == records[j].DateOfEnrollment {
+			return records[i].StudentName < records[j].StudentName
+		}
+		return records[i].DateOfEnrollment < records[j].DateOfEnrollment
+	})
+
+	return c.JSON(fiber.Map{
+		"employeeId":      s.EmployeeID,
+		"name":            s.Name,
+		"matchedAs":       names,
+		"sales":           count,
+		"credit":          credit.rounded(),
+		"records":         records,
+		"unparsedAmounts": unparsed,
+	})
+}
+
+// saleRole names the role a staff member was credited in, or "" for none.
+func saleRole(l1, l2l3 bool) string {
+	switch {
+	case l1 && l2l3:
+		return "L1+L2L3"
+	case l1:
+		return "L1"
+	case l2l3:
+		return "L2L3"
+	}
+	return ""
+}
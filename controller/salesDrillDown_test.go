@@ -0,0 +1,20 @@
+package controller
+
+import "testing"
+
+func TestSaleRole(t *testing.T) {
+	tests := []struct {
+		l1, l2l3 bool
+		want     string
+	}{
+		{true, true, "L1+L2L3"},
+		{true, false, "L1"},
+		{false, true, "L2L3"},
+		{false, false, ""},
+	}
+	for _, tt := range tests {
+		if got := saleRole(tt.l1, tt.l2l3); got != tt.want {
+			t.Errorf("saleRole(%v, %v) = %q, want %q", tt.l1, tt.l2l3, got, tt.want)
+		}
+	}
+}
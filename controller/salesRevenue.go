@@ -0,0 +1,344 @@
+package controller
+
+import (
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// currencyPrefixes are stripped from sheet money strings, longest first.
+var currencyPrefixes = []string{"rs.", "inr", "rs", "₹"}
+
+// UnparsedAmount is a salesleads money value the report could not read; the
+// sale is still counted, valued at 0 for that field.
+type UnparsedAmount struct {
+	ID    string      `json:"id"`
+	Field string      `json:"field"`
+	Value interface{} `json:"value"`
+}
+
+// salesLeadKey identifies a sales lead across staff members, whatever the
+// type of its _id.
+func salesLeadKey(r SalesLead) string {
+	if id, ok := r.ID.(primitive.ObjectID); ok {
+		return id.Hex()
+	}
+	return fmt.Sprint(r.ID)
+}
+
+// saleValue reads a sale's Amount and Fee, listing any value it could not parse.
+func saleValue(r SalesLead) (RevenueTotals, []UnparsedAmount) {
+	sale := RevenueTotals{Sales: 1}
+	var unparsed []UnparsedAmount
+
+	for _, field := range []struct {
+		name string
+		into *float64
+	}{
+		{config.SalesAmountField(), &sale.Amount},
+		{config.SalesFeeField(), &sale.Fee},
+	} {
+		value, err := salesMoney(r.Fields[field.name])
+		if err != nil {
+			unparsed = append(unparsed, UnparsedAmount{ID: salesLeadKey(r), Field: field.name, Value: r.Fields[field.name]})
+			continue
+		}
+		*field.into = value
+	}
+	return sale, unparsed
+}
+
+// salesMoney reads a salesleads money value stored as a number, a Decimal128
+// or a sheet string such as "₹1,25,000/-". A missing value is 0.
+func salesMoney(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case primitive.Decimal128:
+		return strconv.ParseFloat(v.String(), 64)
+	case string:
+		return parseSalesAmount(v)
+	}
+	return 0, fmt.Errorf("unsupported amount type %T", value)
+}
+
+// parseSalesAmount reads a money string such as "Rs. 25,000", "INR 1,25,000.50"
+// or "₹25,000/-". Blank is 0; anything else that is not a number is an error.
+func parseSalesAmount(value string) (float64, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(value))
+	cleaned = strings.TrimSpace(strings.TrimSuffix(cleaned, "/-"))
+
+	negative := strings.HasPrefix(cleaned, "-")
+	cleaned = strings.TrimSpace(strings.TrimPrefix(cleaned, "-"))
+	for _, prefix := range currencyPrefixes {
+		if strings.HasPrefix(cleaned, prefix) {
+			cleaned = strings.TrimSpace(strings.TrimPrefix(cleaned, prefix))
+			break
+		}
+	}
+	if !negative && strings.HasPrefix(cleaned, "-") {
+		negative = true
+		cleaned = strings.TrimPrefix(cleaned, "-")
+	}
+
+	cleaned = strings.NewReplacer(",", "", " ", "").Replace(cleaned)
+	if cleaned == "" {
+		if value = strings.TrimSpace(value); value == "" {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	for _, r := range cleaned {
+		if (r < '0' || r > '9') && r != '.' {
+			return 0, fmt.Errorf("invalid amount %q", value)
+		}
+	}
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// RevenueTotals is the count and value of the sales credited under one role.
+type RevenueTotals struct {
+	Sales  int     `json:"sales"`
+	Amount float64 `json:"amount"`
+	Fee    float64 `json:"fee"`
+}
+
+// RevenueSplit separates revenue by L1 and L2/L3 credit.
+type RevenueSplit struct {
+	L1   RevenueTotals `json:"L1"`
+	L2L3 RevenueTotals `json:"L2L3"`
+}
+
+// StaffRevenue is the revenue credited to one staff member. Range and BySource
+// cover the report dates; ByYear covers the total window.
+type StaffRevenue struct {
+	Name       string                  `json:"name"`
+	Branch     string                  `json:"branch"`
+	EmployeeID string                  `json:"employeeId"`
+	Profile    string                  `json:"profile"`
+	Range      RevenueSplit            `json:"range"`
+	BySource   map[string]RevenueSplit `json:"bySource"`
+	ByYear     map[string]RevenueSplit `json:"byYear"`
+}
+
+type BranchRevenue struct {
+	Branch   string                  `json:"branch"`
+	Range    RevenueSplit            `json:"range"`
+	BySource map[string]RevenueSplit `json:"bySource"`
+	ByYear   map[string]RevenueSplit `json:"byYear"`
+}
+
+// GetRevenueReport values the sales credited to each staff member by Amount
+// and Fee, split by L1 and L2/L3 credit, with totals per branch. A lead credited
+// to several staff of one branch counts once in that branch. Money values that
+// cannot be read are listed under unparsedAmounts.
+func GetRevenueReport(c *fiber.Ctx) error {
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	totalWindow, err := queryTotalWindow(c, endOfDay)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid totalWindow. Use all, fytd, year or rolling with months"})
+	}
+
+	staffList, err := fetchReportStaff(staffFilterFromQuery(c.Query("employeeId"), c.Query("branch"), c.Query("profile")))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	aliases := loadAliasRegistry()
+
+	rangeFilter := bson.M{"Date of Enrollment": bson.M{"$gte": startOfDay, "$lte": endOfDay}}
+	totalFilter := bson.M{}
+	if totalWindow.Bounded() {
+		totalFilter["Date of Enrollment"] = bson.M{"$gte": totalWindow.Start, "$lte": totalWindow.End}
+	}
+
+	staffRevenue := []StaffRevenue{}
+	branches := map[string]*branchLedger{}
+	unparsed := map[string]UnparsedAmount{}
+
+	for _, s := range staffList {
+		names := aliases.names(s, aliasSourceSales)
+		identity := newSalesIdentity(names)
+
+		inRange := identity.revenueSales(fetchSalesLeads(names, rangeFilter), unparsed)
+		inTotal := identity.revenueSales(fetchSalesLeads(names, totalFilter), unparsed)
+
+		revenue := summarizeRevenue(inRange, inTotal)
+		revenue.Name, revenue.Branch, revenue.EmployeeID, revenue.Profile = s.Name, s.Branch, s.EmployeeID, s.Profile
+		staffRevenue = append(staffRevenue, revenue)
+
+		branch, ok := branches[s.Branch]
+		if !ok {
+			branch = &branchLedger{inRange: newRevenueLedger(), inTotal: newRevenueLedger()}
+			branches[s.Branch] = branch
+		}
+		branch.inRange.add(inRange)
+		branch.inTotal.add(inTotal)
+	}
+
+	branchRevenue := []BranchRevenue{}
+	for name, b := range branches {
+		summary := summarizeRevenue(b.inRange.sales(), b.inTotal.sales())
+		branchRevenue = append(branchRevenue, BranchRevenue{Branch: name, Range: summary.Range, BySource: summary.BySource, ByYear: summary.ByYear})
+	}
+	sort.Slice(branchRevenue, func(i, j int) bool {
+		return branchRevenue[i].Branch < branchRevenue[j].Branch
+	})
+
+	return c.JSON(fiber.Map{
+		"staff":           staffRevenue,
+		"branches":        branchRevenue,
+		"totalWindow":     totalWindow,
+		"unparsedAmounts": sortedUnparsed(unparsed),
+	})
+}
+
+// revenueSale is one sales lead and the credit it gives a staff member or branch.
+type revenueSale struct {
+	key    string
+	lead   SalesLead
+	credit RevenueSplit
+}
+
+// revenueSales values the leads credited to this staff member, recording
+// unreadable money values in unparsed by lead and field.
+func (id salesIdentity) revenueSales(leads []SalesLead, unparsed map[string]UnparsedAmount) []revenueSale {
+	var sales []revenueSale
+	for _, r := range leads {
+		l1, l2l3 := id.credits(r.L1), id.credits(r.L2L3)
+		if !l1 && !l2l3 {
+			continue
+		}
+
+		value, invalid := saleValue(r)
+		for _, u := range invalid {
+			unparsed[u.ID+"|"+u.Field] = u
+		}
+
+		sale := revenueSale{key: salesLeadKey(r), lead: r}
+		if l1 {
+			sale.credit.L1 = value
+		}
+		if l2l3 {
+			sale.credit.L2L3 = value
+		}
+		sales = append(sales, sale)
+	}
+	return sales
+}
+
+// summarizeRevenue totals credited sales for the range, by Source, and by
+// Year over the total window.
+func summarizeRevenue(inRange, inTotal []revenueSale) StaffRevenue {
+	revenue := StaffRevenue{BySource: map[string]RevenueSplit{}, ByYear: map[string]RevenueSplit{}}
+
+	for _, sale := range inRange {
+		revenue.Range.add(sale.credit)
+
+		source := salesSourceName(sale.lead.Source)
+		split := revenue.BySource[source]
+		split.add(sale.credit)
+		revenue.BySource[source] = split
+	}
+
+	for _, sale := range inTotal {
+		split := revenue.ByYear[sale.lead.Year]
+		split.add(sale.credit)
+		revenue.ByYear[sale.lead.Year] = split
+	}
+
+	return revenue
+}
+
+// revenueLedger holds each credited lead once. A lead added again, e.g. for
+// another staff member of the same branch, only widens its L1/L2L3 credit.
+type revenueLedger struct {
+	keys  []string
+	byKey map[string]revenueSale
+}
+
+type branchLedger struct {
+	inRange *revenueLedger
+	inTotal *revenueLedger
+}
+
+func newRevenueLedger() *revenueLedger {
+	return &revenueLedger{byKey: map[string]revenueSale{}}
+}
+
+func (l *revenueLedger) add(sales []revenueSale) {
+	for _, sale := range sales {
+		existing, ok := l.byKey[sale.key]
+		if !ok {
+			l.keys = append(l.keys, sale.key)
+			l.byKey[sale.key] = sale
+			continue
+		}
+		if existing.credit.L1.Sales == 0 {
+			existing.credit.L1 = sale.credit.L1
+		}
+		if existing.credit.L2L3.Sales == 0 {
+			existing.credit.L2L3 = sale.credit.L2L3
+		}
+		l.byKey[sale.key] = existing
+	}
+}
+
+// sales lists the ledger in the order leads were first added.
+func (l *revenueLedger) sales() []revenueSale {
+	sales := make([]revenueSale, 0, len(l.keys))
+	for _, key := range l.keys {
+		sales = append(sales, l.byKey[key])
+	}
+	return sales
+}
+
+func sortedUnparsed(unparsed map[string]UnparsedAmount) []UnparsedAmount {
+	list := []UnparsedAmount{}
+	for _, u := range unparsed {
+		list = append(list, u)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ID == list[j].ID {
+			return list[i].Field < list[j].Field
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+func (t *RevenueTotals) add(other RevenueTotals) {
+	t.Sales += other.Sales
+	t.Amount = utils.Round2(t.Amount + other.Amount)
+	t.Fee = utils.Round2(t.Fee + other.Fee)
+}
+
+func (s *RevenueSplit) add(other RevenueSplit) {
+	s.L1.add(other.L1)
+	s.L2L3.add(other.L2L3)
+}
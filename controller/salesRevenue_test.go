@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseSalesAmount(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"  ", 0, false},
+		{"25000", 25000, false},
+		{"Rs. 25,000", 25000, false},
+		{"rs 25,000", 25000, false},
+		{"Rs25000", 25000, false},
+		{"INR 1,25,000.50", 125000.5, false},
+		{"₹25,000/-", 25000, false},
+		{"₹ 1,25,000", 125000, false},
+		{"-500", -500, false},
+		{"-₹500", -500, false},
+		{"₹-500", -500, false},
+		{"12.50", 12.5, false},
+		{"1.2.3", 0, true},
+		{"--5", 0, true},
+		{"5-", 0, true},
+		{"Rs.", 0, true},
+		{"N/A", 0, true},
+		{"25k", 0, true},
+		{"USD 100", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSalesAmount(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseSalesAmount(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSalesMoney(t *testing.T) {
+	decimal, _ := primitive.ParseDecimal128("1250.75")
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    float64
+		wantErr bool
+	}{
+		{"missing", nil, 0, false},
+		{"double", 99.5, 99.5, false},
+		{"int32", int32(40), 40, false},
+		{"int64", int64(70000), 70000, false},
+		{"decimal", decimal, 1250.75, false},
+		{"string", "Rs. 1,000", 1000, false},
+		{"bad string", "pending", 0, true},
+		{"bool", true, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := salesMoney(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("salesMoney(%v) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSaleValue(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	t.Run("default fields", func(t *testing.T) {
+		lead := SalesLead{ID: id, Fields: bson.M{"Amount": "₹25,000/-", "Fee": "later"}}
+		value, unparsed := saleValue(lead)
+		if value != (RevenueTotals{Sales: 1, Amount: 25000}) {
+			t.Errorf("saleValue() = %+v", value)
+		}
+		want := []UnparsedAmount{{ID: id.Hex(), Field: "Fee", Value: "later"}}
+		if !reflect.DeepEqual(unparsed, want) {
+			t.Errorf("saleValue() unparsed = %v, want %v", unparsed, want)
+		}
+	})
+
+	t.Run("configured fields", func(t *testing.T) {
+		t.Setenv("SALES_AMOUNT_FIELD", "Package Amount")
+		t.Setenv("SALES_FEE_FIELD", "Registration Fee")
+		lead := SalesLead{ID: id, Fields: bson.M{"Amount": 1, "Package Amount": int32(50000), "Registration Fee": 2000.0}}
+		value, unparsed := saleValue(lead)
+		if value != (RevenueTotals{Sales: 1, Amount: 50000, Fee: 2000}) || len(unparsed) != 0 {
+			t.Errorf("saleValue() = %+v, %v", value, unparsed)
+		}
+	})
+}
+
+func TestRevenueSales(t *testing.T) {
+	id := newSalesIdentity([]string{"Amit"})
+	leads := []SalesLead{
+		{ID: primitive.NewObjectID(), L1: "Amit L1", Fields: bson.M{"Amount": 100.0}},
+		{ID: primitive.NewObjectID(), L1: "Priya", L2L3: "Amit OV", Fields: bson.M{"Amount": "bad"}},
+		{ID: primitive.NewObjectID(), L1: "Amitabh", Fields: bson.M{"Amount": "bad"}},
+	}
+
+	unparsed := map[string]UnparsedAmount{}
+	sales := id.revenueSales(leads, unparsed)
+
+	if len(sales) != 2 {
+		t.Fatalf("revenueSales() = %d sales, want 2", len(sales))
+	}
+	if sales[0].credit != (RevenueSplit{L1: RevenueTotals{Sales: 1, Amount: 100}}) {
+		t.Errorf("sales[0].credit = %+v", sales[0].credit)
+	}
+	if sales[1].credit != (RevenueSplit{L2L3: RevenueTotals{Sales: 1}}) {
+		t.Errorf("sales[1].credit = %+v", sales[1].credit)
+	}
+	if len(unparsed) != 1 {
+		t.Errorf("unparsed = %v, want only the credited lead", unparsed)
+	}
+}
+
+func TestBranchRevenueCountsLeadOnce(t *testing.T) {
+	shared := SalesLead{ID: primitive.NewObjectID(), L1: "Amit", L2L3: "Priya", Source: "Meta", Year: "2026", Fields: bson.M{"Amount": 1000.0, "Fee": 100.0}}
+	own := SalesLead{ID: primitive.NewObjectID(), L1: "Priya", Source: "Meta", Year: "2026", Fields: bson.M{"Amount": 500.0}}
+	leads := []SalesLead{shared, own}
+
+	amit := newSalesIdentity([]string{"Amit"}).revenueSales(leads, map[string]UnparsedAmount{})
+	priya := newSalesIdentity([]string{"Priya"}).revenueSales(leads, map[string]UnparsedAmount{})
+
+	ledger := newRevenueLedger()
+	ledger.add(amit)
+	ledger.add(priya)
+	branch := summarizeRevenue(ledger.sales(), ledger.sales())
+
+	want := RevenueSplit{
+		L1:   RevenueTotals{Sales: 2, Amount: 1500, Fee: 100},
+		L2L3: RevenueTotals{Sales: 1, Amount: 1000, Fee: 100},
+	}
+	if branch.Range != want {
+		t.Errorf("branch range = %+v, want %+v", branch.Range, want)
+	}
+	if branch.BySource["Meta"] != want || branch.ByYear["2026"] != want {
+		t.Errorf("branch bySource = %+v, byYear = %+v", branch.BySource, branch.ByYear)
+	}
+
+	staff := summarizeRevenue(priya, nil)
+	if staff.Range != (RevenueSplit{L1: RevenueTotals{Sales: 1, Amount: 500}, L2L3: RevenueTotals{Sales: 1, Amount: 1000, Fee: 100}}) {
+		t.Errorf("staff range = %+v", staff.Range)
+	}
+}
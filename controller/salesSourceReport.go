@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// salesSourceUnknown labels sales with no Source recorded.
+const salesSourceUnknown = "Unknown"
+
+// SourceSales counts one staff member's enrollments from one lead source.
+type SourceSales struct {
+	Enrollments int `json:"enrollments"`
+	L1          int `json:"L1"`
+	L2L3        int `json:"L2L3"`
+}
+
+type StaffSourceReport struct {
+	Name       string                 `json:"name"`
+	Branch     string                 `json:"branch"`
+	EmployeeID string                 `json:"employeeId"`
+	Profile    string                 `json:"profile"`
+	Range      map[string]SourceSales `json:"range"`
+	Lifetime   map[string]SourceSales `json:"lifetime"`
+}
+
+// SourcePerformance is one lead source across the organisation. Attributed
+// counts the enrollments credited to at least one of the reported staff.
+type SourcePerformance struct {
+	Source       string  `json:"source"`
+	Enrollments  int     `json:"enrollments"`
+	Share        float64 `json:"share"`
+	Attributed   int     `json:"attributed"`
+	Counsellors  int     `json:"counsellors"`
+	AvgPerSeller float64 `json:"avgPerSeller"`
+}
+
+// sourceTally collects the org-wide source view while staff are processed.
+type sourceTally struct {
+	attributed  map[string]map[string]bool
+	counsellors map[string]int
+}
+
+func newSourceTally() *sourceTally {
+	return &sourceTally{attributed: map[string]map[string]bool{}, counsellors: map[string]int{}}
+}
+
+// GetSalesSourceReport breaks each staff member's enrollments down by lead
+// Source for the range and lifetime (the total window), with an org-wide view
+// of how every source performs.
+func GetSalesSourceReport(c *fiber.Ctx) error {
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	totalWindow, err := queryTotalWindow(c, endOfDay)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid totalWindow. Use all, fytd, year or rolling with months"})
+	}
+
+	staffList, err := fetchReportStaff(staffFilterFromQuery(c.Query("employeeId"), c.Query("branch"), c.Query("profile")))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch staff"})
+	}
+
+	aliases := loadAliasRegistry()
+
+	rangeFilter := bson.M{"Date of Enrollment": bson.M{"$gte": startOfDay, "$lte": endOfDay}}
+	lifetimeFilter := bson.M{}
+	if totalWindow.Bounded() {
+		lifetimeFilter["Date of Enrollment"] = bson.M{"$gte": totalWindow.Start, "$lte": totalWindow.End}
+	}
+
+	rangeTally, lifetimeTally := newSourceTally(), newSourceTally()
+	staffSources := []StaffSourceReport{}
+
+	for _, s := range staffList {
+		names := aliases.names(s, aliasSourceSales)
+		identity := newSalesIdentity(names)
+
+		staffSources = append(staffSources, StaffSourceReport{
+			Name:       s.Name,
+			Branch:     s.Branch,
+			EmployeeID: s.EmployeeID,
+			Profile:    s.Profile,
+			Range:      rangeTally.add(identity, fetchSalesLeads(names, rangeFilter)),
+			Lifetime:   lifetimeTally.add(identity, fetchSalesLeads(names, lifetimeFilter)),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"staff": staffSources,
+		"sources": fiber.Map{
+			"range":    rangeTally.performance(salesSourceCounts(rangeFilter)),
+			"lifetime": lifetimeTally.performance(salesSourceCounts(lifetimeFilter)),
+		},
+		"totalWindow": totalWindow,
+	})
+}
+
+// add counts the leads credited to identity by Source and records them in the tally.
+func (t *sourceTally) add(identity salesIdentity, leads []SalesLead) map[string]SourceSales {
+	bySource := map[string]SourceSales{}
+
+	for _, r := range leads {
+		l1, l2l3 := identity.credits(r.L1), identity.credits(r.L2L3)
+		if !l1 && !l2l3 {
+			continue
+		}
+
+		source := salesSourceName(r.Source)
+		sales := bySource[source]
+		sales.Enrollments++
+		if l1 {
+			sales.L1++
+		}
+		if l2l3 {
+			sales.L2L3++
+		}
+		bySource[source] = sales
+
+		if t.attributed[source] == nil {
+			t.attributed[source] = map[string]bool{}
+		}
+		t.attributed[source][salesLeadKey(r)] = true
+	}
+
+	for source := range bySource {
+		t.counsellors[source]++
+	}
+
+	return bySource
+}
+
+// performance combines the tally with all enrollments per source, best source first.
+func (t *sourceTally) performance(enrollments map[string]int) []SourcePerformance {
+	total := 0
+	for _, n := range enrollments {
+		total += n
+	}
+
+	list := []SourcePerformance{}
+	for source, n := range enrollments {
+		p := SourcePerformance{
+			Source:      source,
+			Enrollments: n,
+			Share:       utils.Percent(float64(n), float64(total)),
+			Attributed:  len(t.attributed[source]),
+			Counsellors: t.counsellors[source],
+		}
+		if p.Counsellors > 0 {
+			p.AvgPerSeller = utils.Round2(float64(p.Attributed) / float64(p.Counsellors))
+		}
+		list = append(list, p)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Enrollments == list[j].Enrollments {
+			return list[i].Source < list[j].Source
+		}
+		return list[i].Enrollments > list[j].Enrollments
+	})
+
+	return list
+}
+
+// salesSourceCounts counts every salesleads enrollment per Source.
+func salesSourceCounts(filter bson.M) map[string]int {
+	collection := config.GetCollection("ZoomDB", "salesleads")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{"_id": "$Source", "count": bson.M{"$sum": 1}}},
+	}
+
+	counts := map[string]int{}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		fmt.Println("Aggregation error:", err)
+		return counts
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID    interface{} `bson:"_id"`
+		Count int         `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		fmt.Println("Cursor decode error:", err)
+		return counts
+	}
+
+	for _, r := range results {
+		source, _ := r.ID.(string)
+		counts[salesSourceName(source)] += r.Count
+	}
+
+	return counts
+}
+
+// salesSourceName trims a Source value, labelling blanks as Unknown.
+func salesSourceName(source string) string {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return salesSourceUnknown
+	}
+	return source
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSalesLeadDecodesAnyID(t *testing.T) {
+	oid := primitive.NewObjectID()
+
+	tests := []struct {
+		name string
+		id   interface{}
+		want string
+	}{
+		{"object id", oid, oid.Hex()},
+		{"string", "LEAD-0042", "LEAD-0042"},
+		{"number", int32(42), "42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := bson.Marshal(bson.M{"_id": tt.id, "L1": "Amit", "Source": "Meta"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var lead SalesLead
+			if err := bson.Unmarshal(raw, &lead); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := salesLeadKey(lead); got != tt.want {
+				t.Errorf("salesLeadKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSalesSourceName(t *testing.T) {
+	tests := []struct {
+		source, want string
+	}{
+		{" Meta ", "Meta"},
+		{"", "Unknown"},
+		{"   ", "Unknown"},
+	}
+	for _, tt := range tests {
+		if got := salesSourceName(tt.source); got != tt.want {
+			t.Errorf("salesSourceName(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestSourceTally(t *testing.T) {
+	leads := []SalesLead{
+		{ID: "a", L1: "Amit", Source: "Meta"},
+		{ID: "b", L1: "Priya", L2L3: "Amit", Source: "Meta"},
+		{ID: "c", L1: "Priya", Source: "Google"},
+		{ID: "d", L1: "Ravi", Source: "Meta"},
+	}
+
+	tally := newSourceTally()
+	amit := tally.add(newSalesIdentity([]string{"Amit"}), leads)
+	tally.add(newSalesIdentity([]string{"Priya"}), leads)
+
+	wantAmit := map[string]SourceSales{"Meta": {Enrollments: 2, L1: 1, L2L3: 1}}
+	if !reflect.DeepEqual(amit, wantAmit) {
+		t.Errorf("add() = %+v, want %+v", amit, wantAmit)
+	}
+
+	got := tally.performance(map[string]int{"Meta": 6, "Google": 2})
+	want := []SourcePerformance{
+		{Source: "Meta", Enrollments: 6, Share: 75, Attributed: 2, Counsellors: 2, AvgPerSeller: 1},
+		{Source: "Google", Enrollments: 2, Share: 25, Attributed: 1, Counsellors: 1, AvgPerSeller: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("performance() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fetchReportStaff returns the staff included in reports (role "user"),
+// narrowed by any extra filter such as employeeId, branch or profile.
+func fetchReportStaff(extra bson.M) ([]models.Staff, error) {
+	staffCollection := config.GetCollection("ZoomDB", "staffs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetProjection(bson.M{
+		"employeeId": 1,
+		"name":       1,
+		"branch":     1,
+		"profile":    1,
+	})
+
+	filter := bson.M{"role": "user"}
+	for key, value := range extra {
+		filter[key] = value
+	}
+
+	cursor, err := staffCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var staffList []models.Staff
+	if err := cursor.All(ctx, &staffList); err != nil {
+		return nil, err
+	}
+
+	return staffList, nil
+}
+
+// staffFilterFromQuery builds the optional employeeId/branch/profile staff filter.
+func staffFilterFromQuery(employeeID, branch, profile string) bson.M {
+	filter := bson.M{}
+	if employeeID != "" {
+		filter["employeeId"] = employeeID
+	}
+	if branch != "" {
+		filter["branch"] = branch
+	}
+	if profile != "" {
+		filter["profile"] = profile
+	}
+	return filter
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// queryTotalWindow resolves ?totalWindow= (and ?months= for a rolling window)
+// against the report's end date, defaulting to TOTAL_WINDOW.
+func queryTotalWindow(c *fiber.Ctx, anchor time.Time) (utils.TotalWindow, error) {
+	spec := c.Query("totalWindow", config.TotalWindow())
+	if months := c.Query("months"); months != "" && spec == "rolling" {
+		spec = "rolling:" + months
+	}
+	return utils.ResolveTotalWindow(spec, anchor, config.FiscalYearStartMonth())
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"fmt"
+	"go_fiber_Zoom_Report/models"
+	"go_fiber_Zoom_Report/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// getOtherCallReport covers calls to numbers outside every assigned lead list,
+// e.g. personal or off-system calling.
+func getOtherCallReport(callLogs CallSource, staff models.Staff, knownNumbers []string, start, end time.Time) ReportBlock {
+	extra := bson.M{callLogs.PhoneField(): bson.M{"$nin": knownNumbers}}
+
+	return buildCallReport(callLogs, staff, extra, nil, start, end)
+}
+
+// GetUnattributedNumbers lists the unknown numbers behind a staff member's otherReport.
+func GetUnattributedNumbers(c *fiber.Ctx) error {
+	empID := c.Query("employeeId")
+	if empID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "employeeId is required"})
+	}
+
+	startOfDay, endOfDay, err := utils.ParseDateRange(c.Query("fromDate"), c.Query("toDate"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+	}
+
+	callLogs := loadCallSources().get(providerCallLogs)
+
+	leads, err := fetchLeadNumbers(empID)
+	if err != nil {
+		fmt.Println("Error fetching lead numbers:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load lead numbers", "sources": incompleteLeadSources(err)})
+	}
+	extra := bson.M{callLogs.PhoneField(): bson.M{"$nin": leads.all()}}
+
+	records, err := callLogs.Fetch(models.Staff{EmployeeID: empID}, nil, startOfDay, endOfDay, extra)
+	if err != nil {
+		fmt.Println("Error fetching unattributed calls:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch call logs"})
+	}
+
+	numbers := summarizeByNumber(records)
+
+	return c.JSON(fiber.Map{
+		"employeeId":   empID,
+		"totalNumbers": len(numbers),
+		"numbers":      numbers,
+	})
+}
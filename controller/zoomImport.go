@@ -0,0 +1,400 @@
+package controller
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"go_fiber_Zoom_Report/config"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var zoomTimeLayouts = []string{
+	"Jan 2, 2006 03:04 PM",
+	"Jan 2, 2006 03:04:05 PM",
+	"Jan 2, 2006 15:04:05",
+	"01/02/2006 03:04:05 PM",
+	"01/02/2006 03:04 PM",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// zoomTable is one table of a Zoom report export: the section title above it
+// (e.g. "Host Details", "Attendee Details"), its header and rows.
+type zoomTable struct {
+	section string
+	header  map[string]int
+	rows    [][]string
+}
+
+func (t zoomTable) cell(row []string, columns ...string) string {
+	for _, column := range columns {
+		if i, ok := t.header[column]; ok && i < len(row) {
+			if value := strings.TrimSpace(row[i]); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// ZoomSession is the per-webinar result of an import.
+type ZoomSession struct {
+	Team         string `json:"team"`
+	Date         string `json:"date"`
+	WebinarID    string `json:"webinarId"`
+	Topic        string `json:"topic"`
+	Attendees    *int   `json:"attendees,omitempty"`
+	Intrested    *int   `json:"intrested,omitempty"`
+	Registration *int   `json:"registration,omitempty"`
+	Inserted     bool   `json:"inserted"`
+}
+
+// ImportZoomReport loads a Zoom webinar attendee or registration report CSV
+// (multipart field "file") into the attendees collection. The session is
+// credited to the Team its host or a panelist maps to in ZOOM_TEAM_MAP_FILE,
+// unless a "team" form value is given; "date" (YYYY-MM-DD) overrides the
+// webinar start date. Documents are upserted on Team, Date and webinar ID, so
+// re-importing a report replaces its figures instead of adding to them; a
+// hand-entered document for the same Team and Date without a webinar ID is
+// updated rather than duplicated. Only the counts the export contains are set.
+func ImportZoomReport(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "CSV file is required in field \"file\""})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Failed to read uploaded file"})
+	}
+	defer file.Close()
+
+	tables, err := readZoomTables(file)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid CSV: " + err.Error()})
+	}
+
+	var topic, people *zoomTable
+	var organisers []*zoomTable
+	for i := range tables {
+		t := &tables[i]
+		switch {
+		case hasColumn(t, "topic"):
+			topic = t
+		case strings.Contains(t.section, "host") || strings.Contains(t.section, "panelist"):
+			organisers = append(organisers, t)
+		case hasColumn(t, "email") && people == nil:
+			people = t
+		}
+	}
+	if people == nil {
+		return c.Status(400).JSON(fiber.Map{"error": "No attendee or registrant table with an Email column found"})
+	}
+
+	session := ZoomSession{Team: strings.TrimSpace(c.FormValue("team"))}
+	var teams map[string]string
+	if session.Team == "" {
+		if teams, err = config.ZoomTeamMap(); err != nil {
+			fmt.Println("Error loading zoom team map:", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to load ZOOM_TEAM_MAP_FILE"})
+		}
+	}
+
+	var startTime string
+	var registered string
+	if topic != nil && len(topic.rows) > 0 {
+		row := topic.rows[0]
+		session.Topic = topic.cell(row, "topic")
+		session.WebinarID = topic.cell(row, "webinar_id", "meeting_id", "id")
+		startTime = topic.cell(row, "actual_start_time", "start_time")
+		registered = topic.cell(row, "#_registered", "registered")
+		if session.Team == "" {
+			session.Team = zoomTeamFor(teams, topic.cell(row, "host_email"), topic.cell(row, "host_name", "host"))
+		}
+	}
+
+	if session.Team == "" {
+		for _, t := range organisers {
+			for _, row := range t.rows {
+				session.Team = zoomTeamFor(teams, t.cell(row, "email"), zoomDisplayName(t, row))
+				if session.Team != "" {
+					break
+				}
+			}
+			if session.Team != "" {
+				break
+			}
+		}
+	}
+	if session.Team == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Could not map the host or panelists to a Team. Add them to ZOOM_TEAM_MAP_FILE or pass team"})
+	}
+
+	var date time.Time
+	if value := c.FormValue("date"); value != "" {
+		date, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid date format. Use YYYY-MM-DD"})
+		}
+	} else {
+		date = parseZoomTime(startTime)
+		for _, row := range people.rows {
+			if !date.IsZero() {
+				break
+			}
+			date = parseZoomTime(people.cell(row, "join_time"))
+		}
+		if date.IsZero() {
+			return c.Status(400).JSON(fiber.Map{"error": "Could not find the webinar date. Pass date as YYYY-MM-DD"})
+		}
+	}
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	session.Date = date.Format("2006-01-02")
+
+	countZoomPeople(people, &session, registered)
+
+	collection := config.GetCollection("ZoomDB", "attendees")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": zoomSessionUpdate(session, date)}
+	filters := zoomSessionFilters(session, date)
+
+	var result *mongo.UpdateResult
+	for i, filter := range filters {
+		upsert := i == len(filters)-1
+		result, err = collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(upsert))
+		if err != nil {
+			fmt.Println("Error saving attendees:", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to save attendees"})
+		}
+		if result.MatchedCount > 0 {
+			break
+		}
+	}
+	session.Inserted = result.UpsertedCount > 0
+
+	return c.JSON(session)
+}
+
+// zoomSessionUpdate is the $set for an imported session. Counts the export
+// does not contain are left out so they keep their stored values.
+func zoomSessionUpdate(session ZoomSession, date time.Time) bson.M {
+	update := bson.M{
+		"Team":      session.Team,
+		"Date":      date,
+		"Source":    "zoom",
+		"UpdatedAt": time.Now().UTC(),
+	}
+	if session.WebinarID != "" {
+		update["WebinarID"] = session.WebinarID
+	}
+	if session.Topic != "" {
+		update["Topic"] = session.Topic
+	}
+	if session.Attendees != nil {
+		update["Attendees"] = *session.Attendees
+	}
+	if session.Intrested != nil {
+		update["Intrested"] = *session.Intrested
+	}
+	if session.Registration != nil {
+		update["Registration"] = *session.Registration
+	}
+	return update
+}
+
+// zoomSessionFilters lists the filters tried in order to find the stored
+// session: the webinar itself, then a legacy Team/Date document without a
+// webinar ID. The last filter is upserted.
+func zoomSessionFilters(session ZoomSession, date time.Time) []bson.M {
+	legacy := bson.M{"Team": session.Team, "Date": date}
+	if session.WebinarID == "" {
+		return []bson.M{legacy}
+	}
+	legacy["WebinarID"] = bson.M{"$exists": false}
+	return []bson.M{
+		{"Team": session.Team, "Date": date, "WebinarID": session.WebinarID},
+		legacy,
+	}
+}
+
+// countZoomPeople fills the session counts from the attendee/registrant table.
+// Attendee reports list registrants who did not join with Attended = "No";
+// a participant-only export (join times, no Attended column) says nothing
+// about registrations unless the topic table gives "# Registered".
+func countZoomPeople(people *zoomTable, session *ZoomSession, registered string) {
+	interestedColumn := normalizeCSVHeader(config.ZoomInterestedColumn())
+	_, hasInterested := people.header[interestedColumn]
+	_, hasAttended := people.header["attended"]
+	_, hasJoinTime := people.header["join_time"]
+	isParticipantReport := hasAttended || hasJoinTime
+
+	registrants := map[string]bool{}
+	attended := map[string]bool{}
+	interested := map[string]bool{}
+
+	for _, row := range people.rows {
+		key := strings.ToLower(people.cell(row, "email"))
+		if key == "" {
+			key = strings.ToLower(zoomDisplayName(people, row))
+		}
+		if key == "" {
+			continue
+		}
+
+		if status := strings.ToLower(people.cell(row, "approval_status")); status != "denied" && status != "cancelled" {
+			registrants[key] = true
+		}
+
+		joined := people.cell(row, "join_time") != ""
+		if hasAttended {
+			joined = strings.EqualFold(people.cell(row, "attended"), "yes")
+		}
+		if joined {
+			attended[key] = true
+		}
+
+		if hasInterested && interestedColumn != "" {
+			switch strings.ToLower(people.cell(row, interestedColumn)) {
+			case "yes", "y", "true", "1", "interested":
+				interested[key] = true
+			}
+		}
+	}
+
+	registration := -1
+	if !isParticipantReport || hasAttended {
+		registration = len(registrants)
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(registered)); err == nil && n > registration {
+		registration = n
+	}
+	if registration >= 0 {
+		session.Registration = &registration
+	}
+
+	if isParticipantReport {
+		count := len(attended)
+		session.Attendees = &count
+	}
+	if hasInterested && interestedColumn != "" {
+		count := len(interested)
+		session.Intrested = &count
+	}
+}
+
+// readZoomTables splits a Zoom export into its tables. Single-cell rows are
+// section titles; the first row after a title, or any row with a Topic or
+// Email column, is a header.
+func readZoomTables(r io.Reader) ([]zoomTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var tables []zoomTable
+	section := ""
+	expectHeader := false
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var cells []string
+		for _, cell := range record {
+			if strings.TrimSpace(cell) != "" {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) == 0 {
+			continue
+		}
+
+		// Metadata lines such as "Report Generated:,<date>"
+		if strings.HasSuffix(strings.TrimSpace(record[0]), ":") {
+			continue
+		}
+
+		if len(cells) == 1 && !strings.Contains(cells[0], "@") {
+			section = strings.ToLower(strings.TrimSpace(cells[0]))
+			expectHeader = true
+			continue
+		}
+
+		header := map[string]int{}
+		for i, name := range record {
+			header[normalizeCSVHeader(name)] = i
+		}
+		_, hasTopic := header["topic"]
+		_, hasEmail := header["email"]
+
+		if expectHeader || hasTopic || hasEmail {
+			tables = append(tables, zoomTable{section: section, header: header})
+			expectHeader = false
+			continue
+		}
+
+		if len(tables) > 0 {
+			last := &tables[len(tables)-1]
+			last.rows = append(last.rows, record)
+		}
+	}
+
+	return tables, nil
+}
+
+func hasColumn(t *zoomTable, column string) bool {
+	_, ok := t.header[column]
+	return ok
+}
+
+// zoomDisplayName reads the participant name, dropping Zoom's "(Original Name)" suffix.
+func zoomDisplayName(t *zoomTable, row []string) string {
+	name := t.cell(row, "user_name_original_name", "user_name", "name")
+	if name == "" {
+		name = strings.TrimSpace(t.cell(row, "first_name") + " " + t.cell(row, "last_name"))
+	}
+	if i := strings.Index(name, " ("); i > 0 {
+		name = name[:i]
+	}
+	return strings.TrimSpace(name)
+}
+
+func zoomTeamFor(teams map[string]string, email, name string) string {
+	if team, ok := teams[strings.ToLower(strings.TrimSpace(email))]; ok && email != "" {
+		return team
+	}
+	if team, ok := teams[strings.ToLower(strings.TrimSpace(name))]; ok && name != "" {
+		return team
+	}
+	return ""
+}
+
+func parseZoomTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range zoomTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const zoomAttendeeExport = `Attendee Report
+Report Generated:,"Mar 3, 2026 10:00 AM"
+Topic,Webinar ID,Actual Start Time,# Registered
+Career Webinar,812 3456 7890,"Mar 2, 2026 06:00 PM",5
+Host Details
+Attended,User Name (Original Name),Email
+Yes,Asha Rao,asha@example.com
+Attendee Details
+Attended,User Name (Original Name),Email,Join Time,Interested
+Yes,Ravi (Ravi K),ravi@example.com,"Mar 2, 2026 06:01 PM",Yes
+No,Meena,meena@example.com,,
+Yes,Ravi,RAVI@example.com,"Mar 2, 2026 06:20 PM",Yes
+`
+
+func TestReadZoomTables(t *testing.T) {
+	tables, err := readZoomTables(strings.NewReader(zoomAttendeeExport))
+	if err != nil {
+		t.Fatalf("readZoomTables() error = %v", err)
+	}
+
+	tests := []struct {
+		section string
+		column  string
+		rows    int
+	}{
+		{"attendee report", "topic", 1},
+		{"host details", "email", 1},
+		{"attendee details", "join_time", 3},
+	}
+	if len(tables) != len(tests) {
+		t.Fatalf("readZoomTables() = %d tables, want %d", len(tables), len(tests))
+	}
+	for i, tt := range tests {
+		if tables[i].section != tt.section || !hasColumn(&tables[i], tt.column) || len(tables[i].rows) != tt.rows {
+			t.Errorf("table %d = {%q, %v, %d rows}, want {%q, %q, %d rows}",
+				i, tables[i].section, tables[i].header, len(tables[i].rows), tt.section, tt.column, tt.rows)
+		}
+	}
+	if got := tables[0].cell(tables[0].rows[0], "webinar_id"); got != "812 3456 7890" {
+		t.Errorf("webinar_id = %q", got)
+	}
+}
+
+func zoomPeopleTable(t *testing.T, csv string) *zoomTable {
+	t.Helper()
+	tables, err := readZoomTables(strings.NewReader(csv))
+	if err != nil || len(tables) != 1 {
+		t.Fatalf("readZoomTables() = %v, %v", tables, err)
+	}
+	return &tables[0]
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestCountZoomPeople(t *testing.T) {
+	t.Setenv("ZOOM_INTERESTED_COLUMN", "Interested")
+
+	tests := []struct {
+		name       string
+		csv        string
+		registered string
+		want       ZoomSession
+	}{
+		{
+			name: "attendee report",
+			csv: "Attended,User Name,Email,Join Time,Interested\n" +
+				"Yes,Ravi,ravi@example.com,\"Mar 2, 2026 06:01 PM\",Yes\n" +
+				"No,Meena,meena@example.com,,\n" +
+				"Yes,Ravi,RAVI@example.com,\"Mar 2, 2026 06:20 PM\",yes\n",
+			want: ZoomSession{Attendees: intPtr(1), Intrested: intPtr(1), Registration: intPtr(2)},
+		},
+		{
+			name:       "registered count from topic table",
+			csv:        "Attended,User Name,Email\nYes,Ravi,ravi@example.com\n",
+			registered: "40",
+			want:       ZoomSession{Attendees: intPtr(1), Registration: intPtr(40)},
+		},
+		{
+			name: "participant export leaves registration alone",
+			csv: "User Name,Email,Join Time\n" +
+				"Ravi,ravi@example.com,\"Mar 2, 2026 06:01 PM\"\n" +
+				"Meena,meena@example.com,\"Mar 2, 2026 06:02 PM\"\n",
+			want: ZoomSession{Attendees: intPtr(2)},
+		},
+		{
+			name: "registration report skips denied",
+			csv: "First Name,Last Name,Email,Approval Status\n" +
+				"Ravi,K,ravi@example.com,approved\n" +
+				"Meena,S,meena@example.com,denied\n" +
+				"Asha,R,,approved\n",
+			want: ZoomSession{Registration: intPtr(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ZoomSession
+			countZoomPeople(zoomPeopleTable(t, tt.csv), &got, tt.registered)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("countZoomPeople() = %s, want %s", zoomCounts(got), zoomCounts(tt.want))
+			}
+		})
+	}
+}
+
+func zoomCounts(s ZoomSession) string {
+	show := func(n *int) string {
+		if n == nil {
+			return "nil"
+		}
+		return strconv.Itoa(*n)
+	}
+	return fmt.Sprintf("{attendees %s, intrested %s, registration %s}", show(s.Attendees), show(s.Intrested), show(s.Registration))
+}
+
+func TestZoomDisplayName(t *testing.T) {
+	tests := []struct {
+		csv  string
+		want string
+	}{
+		{"User Name (Original Name),Email\nRavi (Ravi K),r@example.com\n", "Ravi"},
+		{"Name,Email\n Meena ,m@example.com\n", "Meena"},
+		{"First Name,Last Name,Email\nAsha,Rao,a@example.com\n", "Asha Rao"},
+	}
+	for _, tt := range tests {
+		table := zoomPeopleTable(t, tt.csv)
+		if got := zoomDisplayName(table, table.rows[0]); got != tt.want {
+			t.Errorf("zoomDisplayName() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestZoomTeamFor(t *testing.T) {
+	teams := map[string]string{"asha@example.com": "Asha Rao", "ravi kumar": "Ravi Kumar"}
+
+	tests := []struct {
+		name, email, user, want string
+	}{
+		{"by email", " ASHA@example.com ", "", "Asha Rao"},
+		{"by name", "other@example.com", "Ravi Kumar", "Ravi Kumar"},
+		{"unknown", "x@example.com", "Someone", ""},
+		{"empty", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zoomTeamFor(teams, tt.email, tt.user); got != tt.want {
+				t.Errorf("zoomTeamFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseZoomTime(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"Mar 2, 2026 06:00 PM", time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)},
+		{"03/02/2026 18:05:09", time.Date(2026, 3, 2, 18, 5, 9, 0, time.UTC)},
+		{" 2026-03-02 ", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"", time.Time{}},
+		{"yesterday", time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := parseZoomTime(tt.value); !got.Equal(tt.want) {
+			t.Errorf("parseZoomTime(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestZoomSessionUpdate(t *testing.T) {
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		session ZoomSession
+		want    []string
+	}{
+		{"participant export", ZoomSession{Team: "Asha Rao", Attendees: intPtr(3)}, []string{"Attendees"}},
+		{"registration export", ZoomSession{Team: "Asha Rao", WebinarID: "81234", Registration: intPtr(9)}, []string{"Registration", "WebinarID"}},
+		{"attendee export", ZoomSession{Team: "Asha Rao", Topic: "Careers", Attendees: intPtr(3), Intrested: intPtr(1), Registration: intPtr(9)},
+			[]string{"Attendees", "Intrested", "Registration", "Topic"}},
+	}
+	optional := []string{"Attendees", "Intrested", "Registration", "Topic", "WebinarID"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := zoomSessionUpdate(tt.session, date)
+			if update["Team"] != tt.session.Team || update["Date"] != date || update["Source"] != "zoom" {
+				t.Errorf("zoomSessionUpdate() = %v", update)
+			}
+			var got []string
+			for _, key := range optional {
+				if _, ok := update[key]; ok {
+					got = append(got, key)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("zoomSessionUpdate() sets %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZoomSessionFilters(t *testing.T) {
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		session ZoomSession
+		want    []bson.M
+	}{
+		{
+			"no webinar id",
+			ZoomSession{Team: "Asha Rao"},
+			[]bson.M{{"Team": "Asha Rao", "Date": date}},
+		},
+		{
+			"webinar then legacy",
+			ZoomSession{Team: "Asha Rao", WebinarID: "81234"},
+			[]bson.M{
+				{"Team": "Asha Rao", "Date": date, "WebinarID": "81234"},
+				{"Team": "Asha Rao", "Date": date, "WebinarID": bson.M{"$exists": false}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zoomSessionFilters(tt.session, date); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("zoomSessionFilters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"go_fiber_Zoom_Report/config"
+	"go_fiber_Zoom_Report/controller"
 	"go_fiber_Zoom_Report/routes"
 	"log"
 	"os"
@@ -30,7 +31,24 @@ func main() {
 
 	config.ConnectMongo()
 
+	if err := config.LoadCallProviders(); err != nil {
+		log.Fatal("Invalid call providers: ", err)
+	}
+	if _, err := config.CommissionRules(); err != nil {
+		log.Fatal("Invalid commission rules: ", err)
+	}
+	if err := controller.EnsureCallLogIndex(); err != nil {
+		log.Fatal("Failed to create call log index: ", err)
+	}
+	if err := controller.EnsureReportSnapshotIndex(); err != nil {
+		log.Fatal("Failed to create report snapshot index: ", err)
+	}
+
 	routes.ReportRoutes(app)
+	routes.CallLogRoutes(app)
+	routes.AttendeeRoutes(app)
+	routes.CommissionRoutes(app)
+	routes.PeriodRoutes(app)
 
 	app.Get("/api/v1", func(c *fiber.Ctx) error {
 		return c.SendString("Hello Fiber")
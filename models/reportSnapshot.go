@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ReportSnapshot is a named, persisted copy of one generated report response.
+type ReportSnapshot struct {
+	Name        string            `bson:"name" json:"name"`
+	Path        string            `bson:"path" json:"path"`
+	Filters     map[string]string `bson:"filters" json:"filters"`
+	FromDate    string            `bson:"fromDate" json:"fromDate"`
+	ToDate      string            `bson:"toDate" json:"toDate"`
+	GeneratedAt time.Time         `bson:"generatedAt" json:"generatedAt"`
+	Payload     interface{}       `bson:"payload" json:"payload,omitempty"`
+}
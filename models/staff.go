@@ -15,5 +15,5 @@ type Staff struct {
 	EmployeeID string `bson:"employeeId" json:"employeeId"`
 	Name       string `bson:"name" json:"name"`
 	Branch     string `bson:"branch" json:"branch"`
-	Profile    string `bson:"profile", json:"profile"`
+	Profile    string `bson:"profile" json:"profile"`
 }
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// StaffAlias lists the name variants an employee appears under in one
+// name-joined collection (avyuktacalls, attendees or salesleads).
+type StaffAlias struct {
+	EmployeeID string    `bson:"employeeId" json:"employeeId"`
+	Source     string    `bson:"source" json:"source"`
+	Names      []string  `bson:"names" json:"names"`
+	UpdatedAt  time.Time `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"go_fiber_Zoom_Report/controller"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AttendeeRoutes(app *fiber.App) {
+	app.Post("/attendees/import", controller.ImportZoomReport)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"go_fiber_Zoom_Report/controller"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func CallLogRoutes(app *fiber.App) {
+	app.Post("/calllogs", controller.IngestCallLogs)
+	app.Post("/avyukta/upload", controller.UploadAvyuktaCSV)
+	app.Get("/calllogs/duplicates", controller.GetDuplicateCallLogs)
+	app.Post("/calllogs/duplicates/merge", controller.RequireAdmin, controller.MergeDuplicateCallLogs)
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"go_fiber_Zoom_Report/controller"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func CommissionRoutes(app *fiber.App) {
+	app.Get("/commission/statements", controller.GetCommissionStatements)
+	app.Get("/commission/payout.csv", controller.ExportCommissionPayout)
+}
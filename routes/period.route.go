@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"go_fiber_Zoom_Report/controller"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func PeriodRoutes(app *fiber.App) {
+	app.Get("/periods", controller.GetClosedPeriods)
+	app.Post("/periods/close", controller.RequireAdmin, controller.ClosePeriod)
+	app.Post("/periods/:month/reopen", controller.RequireAdmin, controller.ReopenPeriod)
+}
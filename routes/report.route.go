@@ -7,6 +7,26 @@ import (
 )
 
 func ReportRoutes(app *fiber.App) {
+	// ?snapshot=<name> on any report saves its response as a named snapshot
+	app.Use("/report", controller.SaveReportSnapshot)
+	app.Use("/DailyReport", controller.SaveReportSnapshot)
+
 	app.Get("/report", controller.GetCombineReport)
 	app.Get("/DailyReport", controller.DayByReportEveryStaff)
+	app.Get("/report/unattributed", controller.GetUnattributedNumbers)
+	app.Get("/report/coverage", controller.GetLeadCoverageReport)
+	app.Get("/report/followups", controller.GetFollowUpComplianceReport)
+	app.Get("/report/funnel", controller.GetFunnelReport)
+	app.Get("/report/revenue", controller.GetRevenueReport)
+	app.Get("/report/sources", controller.GetSalesSourceReport)
+	app.Get("/report/leaderboard", controller.GetSalesLeaderboard)
+	app.Get("/report/sales", controller.GetStaffSalesDrillDown)
+
+	app.Get("/snapshots", controller.GetReportSnapshots)
+	app.Get("/snapshots/diff", controller.DiffReportSnapshots)
+	app.Get("/snapshots/:name", controller.GetReportSnapshot)
+
+	app.Get("/aliases", controller.GetStaffAliases)
+	app.Post("/aliases", controller.RequireAdmin, controller.UpsertStaffAlias)
+	app.Get("/aliases/unmatched", controller.GetUnmatchedStaffNames)
 }
@@ -0,0 +1,16 @@
+package utils
+
+import "math"
+
+// Round2 rounds a value to two decimal places for JSON output.
+func Round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// Percent returns part as a percentage of whole, or 0 when whole is 0.
+func Percent(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return Round2(part / whole * 100)
+}
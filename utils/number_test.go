@@ -0,0 +1,42 @@
+package utils
+
+import "testing"
+
+func TestRound2(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  float64
+	}{
+		{0, 0},
+		{1.234, 1.23},
+		{1.235, 1.24},
+		{-2.5551, -2.56},
+		{100, 100},
+	}
+	for _, tt := range tests {
+		if got := Round2(tt.value); got != tt.want {
+			t.Errorf("Round2(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name        string
+		part, whole float64
+		want        float64
+	}{
+		{"zero whole", 5, 0, 0},
+		{"zero part", 0, 10, 0},
+		{"half", 5, 10, 50},
+		{"rounded", 1, 3, 33.33},
+		{"over one hundred", 15, 10, 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Percent(tt.part, tt.whole); got != tt.want {
+				t.Errorf("Percent(%v, %v) = %v, want %v", tt.part, tt.whole, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TotalWindow is the date window "total" metrics are computed over.
+// An all-time window has zero Start and End.
+type TotalWindow struct {
+	Kind   string    `json:"kind"`
+	Months int       `json:"months,omitempty"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
+// Bounded reports whether the window has a date filter at all.
+func (w TotalWindow) Bounded() bool {
+	return w.Kind != "all"
+}
+
+// ResolveTotalWindow turns a window spec into dates ending at anchor:
+//
+//   - "all": no date filter
+//   - "fytd": fiscal year to date, the fiscal year starting in fyStart
+//   - "year": calendar year to date
+//   - "rolling:N": the N months before anchor, starting the day after the
+//     same day N months earlier (clamped to the end of shorter months)
+func ResolveTotalWindow(spec string, anchor time.Time, fyStart time.Month) (TotalWindow, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	kind, arg, _ := strings.Cut(spec, ":")
+
+	end := time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 23, 59, 59, 999000000, time.UTC)
+
+	switch kind {
+	case "", "all":
+		return TotalWindow{Kind: "all"}, nil
+	case "fytd":
+		start := FiscalYearStart(anchor, fyStart)
+		return TotalWindow{Kind: "fytd", Start: start, End: end}, nil
+	case "year":
+		start := time.Date(anchor.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return TotalWindow{Kind: "year", Start: start, End: end}, nil
+	case "rolling":
+		months, err := strconv.Atoi(arg)
+		if err != nil || months < 1 {
+			return TotalWindow{}, fmt.Errorf("rolling window needs a month count, e.g. rolling:12")
+		}
+		start := addMonthsClamped(anchor, -months).AddDate(0, 0, 1)
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		return TotalWindow{Kind: "rolling", Months: months, Start: start, End: end}, nil
+	}
+
+	return TotalWindow{}, fmt.Errorf("unknown window %q", spec)
+}
+
+// addMonthsClamped moves t by months, keeping its day but clamping it to the
+// last day of the target month, so Mar 31 minus one month is Feb 28 (or 29).
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// FiscalYearStart returns the first day of the fiscal year containing t.
+func FiscalYearStart(t time.Time, fyStart time.Month) time.Time {
+	year := t.Year()
+	if t.Month() < fyStart {
+		year--
+	}
+	return time.Date(year, fyStart, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// FiscalYearLabel names the fiscal year containing t, e.g. "FY2024-25" for an
+// April start, or "FY2024" when the fiscal year is the calendar year.
+func FiscalYearLabel(t time.Time, fyStart time.Month) string {
+	start := FiscalYearStart(t, fyStart).Year()
+	if fyStart == time.January {
+		return fmt.Sprintf("FY%d", start)
+	}
+	return fmt.Sprintf("FY%d-%02d", start, (start+1)%100)
+}
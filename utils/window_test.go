@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestResolveTotalWindow(t *testing.T) {
+	anchor := time.Date(2026, 3, 31, 15, 30, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 23, 59, 59, 999000000, time.UTC)
+
+	tests := []struct {
+		name   string
+		spec   string
+		anchor time.Time
+		want   TotalWindow
+	}{
+		{"empty is all", "", anchor, TotalWindow{Kind: "all"}},
+		{"all", " ALL ", anchor, TotalWindow{Kind: "all"}},
+		{"fytd", "fytd", anchor, TotalWindow{Kind: "fytd", Start: day(2025, time.April, 1), End: end}},
+		{"year", "year", anchor, TotalWindow{Kind: "year", Start: day(2026, time.January, 1), End: end}},
+		{"rolling from the 31st", "rolling:1", anchor, TotalWindow{Kind: "rolling", Months: 1, Start: day(2026, time.March, 1), End: end}},
+		{"rolling mid month", "rolling:3", day(2026, time.May, 15), TotalWindow{Kind: "rolling", Months: 3,
+			Start: day(2026, time.February, 16), End: time.Date(2026, 5, 15, 23, 59, 59, 999000000, time.UTC)}},
+		{"rolling from Feb 29", "rolling:12", day(2024, time.February, 29), TotalWindow{Kind: "rolling", Months: 12,
+			Start: day(2023, time.March, 1), End: time.Date(2024, 2, 29, 23, 59, 59, 999000000, time.UTC)}},
+		{"rolling across the year boundary", "rolling:2", day(2026, time.January, 15), TotalWindow{Kind: "rolling", Months: 2,
+			Start: day(2025, time.November, 16), End: time.Date(2026, 1, 15, 23, 59, 59, 999000000, time.UTC)}},
+		{"rolling from Dec 31", "rolling:1", day(2025, time.December, 31), TotalWindow{Kind: "rolling", Months: 1,
+			Start: day(2025, time.December, 1), End: time.Date(2025, 12, 31, 23, 59, 59, 999000000, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveTotalWindow(tt.spec, tt.anchor, time.April)
+			if err != nil {
+				t.Fatalf("ResolveTotalWindow(%q) error = %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTotalWindow(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+
+	for _, spec := range []string{"rolling", "rolling:0", "rolling:x", "quarter"} {
+		if _, err := ResolveTotalWindow(spec, anchor, time.April); err == nil {
+			t.Errorf("ResolveTotalWindow(%q) error = nil, want error", spec)
+		}
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	tests := []struct {
+		from   time.Time
+		months int
+		want   time.Time
+	}{
+		{day(2026, time.March, 31), -1, day(2026, time.February, 28)},
+		{day(2024, time.March, 31), -1, day(2024, time.February, 29)},
+		{day(2024, time.February, 29), -12, day(2023, time.February, 28)},
+		{day(2026, time.January, 31), -2, day(2025, time.November, 30)},
+		{day(2026, time.January, 15), -1, day(2025, time.December, 15)},
+		{day(2025, time.October, 31), 4, day(2026, time.February, 28)},
+	}
+	for _, tt := range tests {
+		if got := addMonthsClamped(tt.from, tt.months); !got.Equal(tt.want) {
+			t.Errorf("addMonthsClamped(%v, %d) = %v, want %v", tt.from.Format("2006-01-02"), tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestFiscalYearStart(t *testing.T) {
+	tests := []struct {
+		t       time.Time
+		fyStart time.Month
+		want    time.Time
+	}{
+		{day(2026, time.March, 31), time.April, day(2025, time.April, 1)},
+		{day(2026, time.April, 1), time.April, day(2026, time.April, 1)},
+		{day(2026, time.December, 31), time.January, day(2026, time.January, 1)},
+		{day(2026, time.June, 30), time.July, day(2025, time.July, 1)},
+	}
+	for _, tt := range tests {
+		if got := FiscalYearStart(tt.t, tt.fyStart); !got.Equal(tt.want) {
+			t.Errorf("FiscalYearStart(%v, %v) = %v, want %v", tt.t.Format("2006-01-02"), tt.fyStart, got, tt.want)
+		}
+	}
+}
+
+func TestFiscalYearLabel(t *testing.T) {
+	tests := []struct {
+		t       time.Time
+		fyStart time.Month
+		want    string
+	}{
+		{day(2025, time.March, 31), time.April, "FY2024-25"},
+		{day(2025, time.April, 1), time.April, "FY2025-26"},
+		{day(2099, time.December, 31), time.April, "FY2099-00"},
+		{day(2026, time.July, 1), time.July, "FY2026-27"},
+		{day(2026, time.June, 30), time.January, "FY2026"},
+	}
+	for _, tt := range tests {
+		if got := FiscalYearLabel(tt.t, tt.fyStart); got != tt.want {
+			t.Errorf("FiscalYearLabel(%v, %v) = %q, want %q", tt.t.Format("2006-01-02"), tt.fyStart, got, tt.want)
+		}
+	}
+}